@@ -20,9 +20,46 @@ import (
 type AssetAttributeAPIRouter interface {
 	DeleteAssetAttributes(http.ResponseWriter, *http.Request)
 	GetAssetAttributes(http.ResponseWriter, *http.Request)
+	GetReferenceCollisions(http.ResponseWriter, *http.Request)
+	LintAssetAttributes(http.ResponseWriter, *http.Request)
 	PutAssetAttribute(http.ResponseWriter, *http.Request)
 }
 
+// MappingRuleAPIRouter defines the required methods for binding the api requests to a responses for the MappingRuleAPI
+// The MappingRuleAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a MappingRuleAPIServicer to perform the required actions, then write the service results to the http response.
+type MappingRuleAPIRouter interface {
+	DeleteMappingRuleById(http.ResponseWriter, *http.Request)
+	GetMappingRuleById(http.ResponseWriter, *http.Request)
+	GetMappingRules(http.ResponseWriter, *http.Request)
+	PostMappingRule(http.ResponseWriter, *http.Request)
+	PreviewMappingRule(http.ResponseWriter, *http.Request)
+	PutMappingRuleById(http.ResponseWriter, *http.Request)
+	ReconcileMappingRuleById(http.ResponseWriter, *http.Request)
+}
+
+// BackfillAPIRouter defines the required methods for binding the api requests to a responses for the BackfillAPI
+// The BackfillAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a BackfillAPIServicer to perform the required actions, then write the service results to the http response.
+type BackfillAPIRouter interface {
+	DeleteBackfillById(http.ResponseWriter, *http.Request)
+	GetBackfillById(http.ResponseWriter, *http.Request)
+	GetBackfills(http.ResponseWriter, *http.Request)
+	PostBackfill(http.ResponseWriter, *http.Request)
+}
+
+// DeadLetterAPIRouter defines the required methods for binding the api requests to a responses for the DeadLetterAPI
+// The DeadLetterAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a DeadLetterAPIServicer to perform the required actions, then write the service results to the http response.
+type DeadLetterAPIRouter interface {
+	DeleteDeadLetterById(http.ResponseWriter, *http.Request)
+	DeleteDeadLetters(http.ResponseWriter, *http.Request)
+	GetDeadLetterById(http.ResponseWriter, *http.Request)
+	GetDeadLetters(http.ResponseWriter, *http.Request)
+	ReplayDeadLetterById(http.ResponseWriter, *http.Request)
+	ReplayDeadLetters(http.ResponseWriter, *http.Request)
+}
+
 // ConfigurationAPIRouter defines the required methods for binding the api requests to a responses for the ConfigurationAPI
 // The ConfigurationAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a ConfigurationAPIServicer to perform the required actions, then write the service results to the http response.
@@ -30,8 +67,10 @@ type ConfigurationAPIRouter interface {
 	DeleteConfigurationById(http.ResponseWriter, *http.Request)
 	GetConfigurationById(http.ResponseWriter, *http.Request)
 	GetConfigurations(http.ResponseWriter, *http.Request)
+	PatchConfigurationById(http.ResponseWriter, *http.Request)
 	PostConfiguration(http.ResponseWriter, *http.Request)
 	PutConfigurationById(http.ResponseWriter, *http.Request)
+	RenderConfigurationReferences(http.ResponseWriter, *http.Request)
 }
 
 // VersionAPIRouter defines the required methods for binding the api requests to a responses for the VersionAPI
@@ -49,19 +88,61 @@ type VersionAPIRouter interface {
 type AssetAttributeAPIServicer interface {
 	DeleteAssetAttributes(context.Context, int32, int32, string, string) (ImplResponse, error)
 	GetAssetAttributes(context.Context, int32, int32, string, string) (ImplResponse, error)
+	GetReferenceCollisions(context.Context, int32) (ImplResponse, error)
+	LintAssetAttributes(context.Context, int32, int32, string, string) (ImplResponse, error)
 	PutAssetAttribute(context.Context, AssetAttribute) (ImplResponse, error)
 }
 
+// MappingRuleAPIServicer defines the api actions for the MappingRuleAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type MappingRuleAPIServicer interface {
+	DeleteMappingRuleById(context.Context, int64) (ImplResponse, error)
+	GetMappingRuleById(context.Context, int64) (ImplResponse, error)
+	GetMappingRules(context.Context, int32) (ImplResponse, error)
+	PostMappingRule(context.Context, MappingRule) (ImplResponse, error)
+	PreviewMappingRule(context.Context, MappingRule) (ImplResponse, error)
+	PutMappingRuleById(context.Context, int64, MappingRule) (ImplResponse, error)
+	ReconcileMappingRuleById(context.Context, int64) (ImplResponse, error)
+}
+
+// BackfillAPIServicer defines the api actions for the BackfillAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type BackfillAPIServicer interface {
+	DeleteBackfillById(context.Context, int64) (ImplResponse, error)
+	GetBackfillById(context.Context, int64) (ImplResponse, error)
+	GetBackfills(context.Context, int32) (ImplResponse, error)
+	PostBackfill(context.Context, Backfill) (ImplResponse, error)
+}
+
+// DeadLetterAPIServicer defines the api actions for the DeadLetterAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type DeadLetterAPIServicer interface {
+	DeleteDeadLetterById(context.Context, int64) (ImplResponse, error)
+	DeleteDeadLetters(context.Context, int32, int32, string, string) (ImplResponse, error)
+	GetDeadLetterById(context.Context, int64) (ImplResponse, error)
+	GetDeadLetters(context.Context, int32, int32, string, string) (ImplResponse, error)
+	ReplayDeadLetterById(context.Context, int64) (ImplResponse, error)
+	ReplayDeadLetters(context.Context, int32, int32, string, string) (ImplResponse, error)
+}
+
 // ConfigurationAPIServicer defines the api actions for the ConfigurationAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type ConfigurationAPIServicer interface {
-	DeleteConfigurationById(context.Context, int64) (ImplResponse, error)
+	DeleteConfigurationById(context.Context, int64, string, int64, bool) (ImplResponse, error)
 	GetConfigurationById(context.Context, int64) (ImplResponse, error)
 	GetConfigurations(context.Context) (ImplResponse, error)
-	PostConfiguration(context.Context, Configuration) (ImplResponse, error)
-	PutConfigurationById(context.Context, int64, Configuration) (ImplResponse, error)
+	PatchConfigurationById(context.Context, int64, bool, string, Configuration) (ImplResponse, error)
+	PostConfiguration(context.Context, bool, Configuration) (ImplResponse, error)
+	PutConfigurationById(context.Context, int64, bool, string, Configuration) (ImplResponse, error)
+	RenderConfigurationReferences(context.Context, int64, bool) (ImplResponse, error)
 }
 
 // VersionAPIServicer defines the api actions for the VersionAPI service
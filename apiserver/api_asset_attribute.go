@@ -60,6 +60,16 @@ func (c *AssetAttributeAPIController) Routes() Routes {
 			"/v1/asset-attributes",
 			c.GetAssetAttributes,
 		},
+		"GetReferenceCollisions": Route{
+			strings.ToUpper("Get"),
+			"/v1/asset-attributes/collisions",
+			c.GetReferenceCollisions,
+		},
+		"LintAssetAttributes": Route{
+			strings.ToUpper("Get"),
+			"/v1/asset-attributes/lint",
+			c.LintAssetAttributes,
+		},
 		"PutAssetAttribute": Route{
 			strings.ToUpper("Put"),
 			"/v1/asset-attributes",
@@ -124,7 +134,7 @@ func (c *AssetAttributeAPIController) DeleteAssetAttributes(w http.ResponseWrite
 		return
 	}
 	// If no error, encode the body and the result code
-	EncodeJSONResponse(result.Body, &result.Code, w)
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
 }
 
 // GetAssetAttributes - Get configured asset attributes
@@ -183,7 +193,97 @@ func (c *AssetAttributeAPIController) GetAssetAttributes(w http.ResponseWriter,
 		return
 	}
 	// If no error, encode the body and the result code
-	EncodeJSONResponse(result.Body, &result.Code, w)
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// GetReferenceCollisions - Lists asset attributes with colliding references
+func (c *AssetAttributeAPIController) GetReferenceCollisions(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var configIdParam int32
+	if query.Has("configId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("configId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		configIdParam = param
+	} else {
+	}
+	result, err := c.service.GetReferenceCollisions(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// LintAssetAttributes - Checks configured asset attributes against Eliona
+func (c *AssetAttributeAPIController) LintAssetAttributes(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var configIdParam int32
+	if query.Has("configId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("configId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		configIdParam = param
+	} else {
+	}
+	var assetIdParam int32
+	if query.Has("assetId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("assetId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		assetIdParam = param
+	} else {
+	}
+	var subtypeParam string
+	if query.Has("subtype") {
+		param := query.Get("subtype")
+
+		subtypeParam = param
+	} else {
+	}
+	var attributeNameParam string
+	if query.Has("attributeName") {
+		param := query.Get("attributeName")
+
+		attributeNameParam = param
+	} else {
+	}
+	result, err := c.service.LintAssetAttributes(r.Context(), configIdParam, assetIdParam, subtypeParam, attributeNameParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
 }
 
 // PutAssetAttribute - Creates or updates a configured asset attribute
@@ -210,5 +310,5 @@ func (c *AssetAttributeAPIController) PutAssetAttribute(w http.ResponseWriter, r
 		return
 	}
 	// If no error, encode the body and the result code
-	EncodeJSONResponse(result.Body, &result.Code, w)
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
 }
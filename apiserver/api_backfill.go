@@ -0,0 +1,176 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// BackfillAPIController binds http requests to an api service and writes the service results to the http response
+type BackfillAPIController struct {
+	service      BackfillAPIServicer
+	errorHandler ErrorHandler
+}
+
+// BackfillAPIOption for how the controller is set up.
+type BackfillAPIOption func(*BackfillAPIController)
+
+// WithBackfillAPIErrorHandler inject ErrorHandler into controller
+func WithBackfillAPIErrorHandler(h ErrorHandler) BackfillAPIOption {
+	return func(c *BackfillAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewBackfillAPIController creates a default api controller
+func NewBackfillAPIController(s BackfillAPIServicer, opts ...BackfillAPIOption) Router {
+	controller := &BackfillAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the BackfillAPIController
+func (c *BackfillAPIController) Routes() Routes {
+	return Routes{
+		"DeleteBackfillById": Route{
+			strings.ToUpper("Delete"),
+			"/v1/backfills/{backfill-id}",
+			c.DeleteBackfillById,
+		},
+		"GetBackfillById": Route{
+			strings.ToUpper("Get"),
+			"/v1/backfills/{backfill-id}",
+			c.GetBackfillById,
+		},
+		"GetBackfills": Route{
+			strings.ToUpper("Get"),
+			"/v1/backfills",
+			c.GetBackfills,
+		},
+		"PostBackfill": Route{
+			strings.ToUpper("Post"),
+			"/v1/backfills",
+			c.PostBackfill,
+		},
+	}
+}
+
+// DeleteBackfillById - Deletes a backfill job
+func (c *BackfillAPIController) DeleteBackfillById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	backfillIdParam, err := parseNumericParameter[int64](
+		params["backfill-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.DeleteBackfillById(r.Context(), backfillIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// GetBackfillById - Get backfill job
+func (c *BackfillAPIController) GetBackfillById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	backfillIdParam, err := parseNumericParameter[int64](
+		params["backfill-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetBackfillById(r.Context(), backfillIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// GetBackfills - Get backfill jobs
+func (c *BackfillAPIController) GetBackfills(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var configIdParam int32
+	if query.Has("configId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("configId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		configIdParam = param
+	} else {
+	}
+	result, err := c.service.GetBackfills(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// PostBackfill - Creates backfill jobs
+func (c *BackfillAPIController) PostBackfill(w http.ResponseWriter, r *http.Request) {
+	backfillParam := Backfill{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&backfillParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertBackfillRequired(backfillParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertBackfillConstraints(backfillParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PostBackfill(r.Context(), backfillParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
@@ -67,6 +67,11 @@ func (c *ConfigurationAPIController) Routes() Routes {
 			"/v1/configs",
 			c.GetConfigurations,
 		},
+		"PatchConfigurationById": Route{
+			strings.ToUpper("Patch"),
+			"/v1/configs/{config-id}",
+			c.PatchConfigurationById,
+		},
 		"PostConfiguration": Route{
 			strings.ToUpper("Post"),
 			"/v1/configs",
@@ -77,12 +82,22 @@ func (c *ConfigurationAPIController) Routes() Routes {
 			"/v1/configs/{config-id}",
 			c.PutConfigurationById,
 		},
+		"RenderConfigurationReferences": Route{
+			strings.ToUpper("Post"),
+			"/v1/configs/{config-id}/render-references",
+			c.RenderConfigurationReferences,
+		},
 	}
 }
 
 // DeleteConfigurationById - Deletes a configuration
 func (c *ConfigurationAPIController) DeleteConfigurationById(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
 	configIdParam, err := parseNumericParameter[int64](
 		params["config-id"],
 		WithRequire[int64](parseInt64),
@@ -91,14 +106,51 @@ func (c *ConfigurationAPIController) DeleteConfigurationById(w http.ResponseWrit
 		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
 		return
 	}
-	result, err := c.service.DeleteConfigurationById(r.Context(), configIdParam)
+	var mappingsParam string
+	if query.Has("mappings") {
+		param := query.Get("mappings")
+
+		mappingsParam = param
+	} else {
+		var param string = "restrict"
+		mappingsParam = param
+	}
+	var reassignToParam int64
+	if query.Has("reassignTo") {
+		param, err := parseNumericParameter[int64](
+			query.Get("reassignTo"),
+			WithParse[int64](parseInt64),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		reassignToParam = param
+	} else {
+	}
+	var dryRunParam bool
+	if query.Has("dryRun") {
+		param, err := parseBoolParameter(
+			query.Get("dryRun"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		dryRunParam = param
+	} else {
+	}
+	result, err := c.service.DeleteConfigurationById(r.Context(), configIdParam, mappingsParam, reassignToParam, dryRunParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
 		return
 	}
 	// If no error, encode the body and the result code
-	EncodeJSONResponse(result.Body, &result.Code, w)
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
 }
 
 // GetConfigurationById - Get configuration
@@ -119,7 +171,7 @@ func (c *ConfigurationAPIController) GetConfigurationById(w http.ResponseWriter,
 		return
 	}
 	// If no error, encode the body and the result code
-	EncodeJSONResponse(result.Body, &result.Code, w)
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
 }
 
 // GetConfigurations - Get configurations
@@ -131,11 +183,86 @@ func (c *ConfigurationAPIController) GetConfigurations(w http.ResponseWriter, r
 		return
 	}
 	// If no error, encode the body and the result code
-	EncodeJSONResponse(result.Body, &result.Code, w)
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// PatchConfigurationById - Partially updates a configuration
+func (c *ConfigurationAPIController) PatchConfigurationById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var verifyParam bool
+	if query.Has("verify") {
+		param, err := parseBoolParameter(
+			query.Get("verify"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		verifyParam = param
+	} else {
+	}
+	ifMatchParam := r.Header.Get("If-Match")
+	configurationParam := Configuration{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&configurationParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertConfigurationRequired(configurationParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertConfigurationConstraints(configurationParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PatchConfigurationById(r.Context(), configIdParam, verifyParam, ifMatchParam, configurationParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
 }
 
 // PostConfiguration - Creates a configuration
 func (c *ConfigurationAPIController) PostConfiguration(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var verifyParam bool
+	if query.Has("verify") {
+		param, err := parseBoolParameter(
+			query.Get("verify"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		verifyParam = param
+	} else {
+	}
 	configurationParam := Configuration{}
 	d := json.NewDecoder(r.Body)
 	d.DisallowUnknownFields()
@@ -151,19 +278,24 @@ func (c *ConfigurationAPIController) PostConfiguration(w http.ResponseWriter, r
 		c.errorHandler(w, r, err, nil)
 		return
 	}
-	result, err := c.service.PostConfiguration(r.Context(), configurationParam)
+	result, err := c.service.PostConfiguration(r.Context(), verifyParam, configurationParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
 		return
 	}
 	// If no error, encode the body and the result code
-	EncodeJSONResponse(result.Body, &result.Code, w)
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
 }
 
 // PutConfigurationById - Updates a configuration
 func (c *ConfigurationAPIController) PutConfigurationById(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
 	configIdParam, err := parseNumericParameter[int64](
 		params["config-id"],
 		WithRequire[int64](parseInt64),
@@ -172,6 +304,21 @@ func (c *ConfigurationAPIController) PutConfigurationById(w http.ResponseWriter,
 		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
 		return
 	}
+	var verifyParam bool
+	if query.Has("verify") {
+		param, err := parseBoolParameter(
+			query.Get("verify"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		verifyParam = param
+	} else {
+	}
+	ifMatchParam := r.Header.Get("If-Match")
 	configurationParam := Configuration{}
 	d := json.NewDecoder(r.Body)
 	d.DisallowUnknownFields()
@@ -187,12 +334,52 @@ func (c *ConfigurationAPIController) PutConfigurationById(w http.ResponseWriter,
 		c.errorHandler(w, r, err, nil)
 		return
 	}
-	result, err := c.service.PutConfigurationById(r.Context(), configIdParam, configurationParam)
+	result, err := c.service.PutConfigurationById(r.Context(), configIdParam, verifyParam, ifMatchParam, configurationParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// RenderConfigurationReferences - Renders the references of a configuration again
+func (c *ConfigurationAPIController) RenderConfigurationReferences(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var dryRunParam bool
+	if query.Has("dryRun") {
+		param, err := parseBoolParameter(
+			query.Get("dryRun"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		dryRunParam = param
+	} else {
+	}
+	result, err := c.service.RenderConfigurationReferences(r.Context(), configIdParam, dryRunParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
 		return
 	}
 	// If no error, encode the body and the result code
-	EncodeJSONResponse(result.Body, &result.Code, w)
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
 }
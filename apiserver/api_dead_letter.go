@@ -0,0 +1,323 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// DeadLetterAPIController binds http requests to an api service and writes the service results to the http response
+type DeadLetterAPIController struct {
+	service      DeadLetterAPIServicer
+	errorHandler ErrorHandler
+}
+
+// DeadLetterAPIOption for how the controller is set up.
+type DeadLetterAPIOption func(*DeadLetterAPIController)
+
+// WithDeadLetterAPIErrorHandler inject ErrorHandler into controller
+func WithDeadLetterAPIErrorHandler(h ErrorHandler) DeadLetterAPIOption {
+	return func(c *DeadLetterAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewDeadLetterAPIController creates a default api controller
+func NewDeadLetterAPIController(s DeadLetterAPIServicer, opts ...DeadLetterAPIOption) Router {
+	controller := &DeadLetterAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the DeadLetterAPIController
+func (c *DeadLetterAPIController) Routes() Routes {
+	return Routes{
+		"DeleteDeadLetterById": Route{
+			strings.ToUpper("Delete"),
+			"/v1/dead-letters/{dead-letter-id}",
+			c.DeleteDeadLetterById,
+		},
+		"DeleteDeadLetters": Route{
+			strings.ToUpper("Delete"),
+			"/v1/dead-letters",
+			c.DeleteDeadLetters,
+		},
+		"GetDeadLetterById": Route{
+			strings.ToUpper("Get"),
+			"/v1/dead-letters/{dead-letter-id}",
+			c.GetDeadLetterById,
+		},
+		"GetDeadLetters": Route{
+			strings.ToUpper("Get"),
+			"/v1/dead-letters",
+			c.GetDeadLetters,
+		},
+		"ReplayDeadLetterById": Route{
+			strings.ToUpper("Post"),
+			"/v1/dead-letters/{dead-letter-id}/replay",
+			c.ReplayDeadLetterById,
+		},
+		"ReplayDeadLetters": Route{
+			strings.ToUpper("Post"),
+			"/v1/dead-letters/replay",
+			c.ReplayDeadLetters,
+		},
+	}
+}
+
+// DeleteDeadLetterById - Discards a dead letter
+func (c *DeadLetterAPIController) DeleteDeadLetterById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	deadLetterIdParam, err := parseNumericParameter[int64](
+		params["dead-letter-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.DeleteDeadLetterById(r.Context(), deadLetterIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// DeleteDeadLetters - Discards dead letters
+func (c *DeadLetterAPIController) DeleteDeadLetters(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var configIdParam int32
+	if query.Has("configId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("configId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		configIdParam = param
+	} else {
+	}
+	var assetIdParam int32
+	if query.Has("assetId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("assetId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		assetIdParam = param
+	} else {
+	}
+	var subtypeParam string
+	if query.Has("subtype") {
+		param := query.Get("subtype")
+
+		subtypeParam = param
+	} else {
+	}
+	var attributeNameParam string
+	if query.Has("attributeName") {
+		param := query.Get("attributeName")
+
+		attributeNameParam = param
+	} else {
+	}
+	result, err := c.service.DeleteDeadLetters(r.Context(), configIdParam, assetIdParam, subtypeParam, attributeNameParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// GetDeadLetterById - Get dead letter
+func (c *DeadLetterAPIController) GetDeadLetterById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	deadLetterIdParam, err := parseNumericParameter[int64](
+		params["dead-letter-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetDeadLetterById(r.Context(), deadLetterIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// GetDeadLetters - Get dead letters
+func (c *DeadLetterAPIController) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var configIdParam int32
+	if query.Has("configId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("configId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		configIdParam = param
+	} else {
+	}
+	var assetIdParam int32
+	if query.Has("assetId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("assetId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		assetIdParam = param
+	} else {
+	}
+	var subtypeParam string
+	if query.Has("subtype") {
+		param := query.Get("subtype")
+
+		subtypeParam = param
+	} else {
+	}
+	var attributeNameParam string
+	if query.Has("attributeName") {
+		param := query.Get("attributeName")
+
+		attributeNameParam = param
+	} else {
+	}
+	result, err := c.service.GetDeadLetters(r.Context(), configIdParam, assetIdParam, subtypeParam, attributeNameParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// ReplayDeadLetterById - Replays a dead letter
+func (c *DeadLetterAPIController) ReplayDeadLetterById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	deadLetterIdParam, err := parseNumericParameter[int64](
+		params["dead-letter-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.ReplayDeadLetterById(r.Context(), deadLetterIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// ReplayDeadLetters - Replays dead letters
+func (c *DeadLetterAPIController) ReplayDeadLetters(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var configIdParam int32
+	if query.Has("configId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("configId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		configIdParam = param
+	} else {
+	}
+	var assetIdParam int32
+	if query.Has("assetId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("assetId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		assetIdParam = param
+	} else {
+	}
+	var subtypeParam string
+	if query.Has("subtype") {
+		param := query.Get("subtype")
+
+		subtypeParam = param
+	} else {
+	}
+	var attributeNameParam string
+	if query.Has("attributeName") {
+		param := query.Get("attributeName")
+
+		attributeNameParam = param
+	} else {
+	}
+	result, err := c.service.ReplayDeadLetters(r.Context(), configIdParam, assetIdParam, subtypeParam, attributeNameParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
@@ -0,0 +1,275 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// MappingRuleAPIController binds http requests to an api service and writes the service results to the http response
+type MappingRuleAPIController struct {
+	service      MappingRuleAPIServicer
+	errorHandler ErrorHandler
+}
+
+// MappingRuleAPIOption for how the controller is set up.
+type MappingRuleAPIOption func(*MappingRuleAPIController)
+
+// WithMappingRuleAPIErrorHandler inject ErrorHandler into controller
+func WithMappingRuleAPIErrorHandler(h ErrorHandler) MappingRuleAPIOption {
+	return func(c *MappingRuleAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewMappingRuleAPIController creates a default api controller
+func NewMappingRuleAPIController(s MappingRuleAPIServicer, opts ...MappingRuleAPIOption) Router {
+	controller := &MappingRuleAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the MappingRuleAPIController
+func (c *MappingRuleAPIController) Routes() Routes {
+	return Routes{
+		"DeleteMappingRuleById": Route{
+			strings.ToUpper("Delete"),
+			"/v1/mapping-rules/{rule-id}",
+			c.DeleteMappingRuleById,
+		},
+		"GetMappingRuleById": Route{
+			strings.ToUpper("Get"),
+			"/v1/mapping-rules/{rule-id}",
+			c.GetMappingRuleById,
+		},
+		"GetMappingRules": Route{
+			strings.ToUpper("Get"),
+			"/v1/mapping-rules",
+			c.GetMappingRules,
+		},
+		"PostMappingRule": Route{
+			strings.ToUpper("Post"),
+			"/v1/mapping-rules",
+			c.PostMappingRule,
+		},
+		"PreviewMappingRule": Route{
+			strings.ToUpper("Post"),
+			"/v1/mapping-rules/preview",
+			c.PreviewMappingRule,
+		},
+		"PutMappingRuleById": Route{
+			strings.ToUpper("Put"),
+			"/v1/mapping-rules/{rule-id}",
+			c.PutMappingRuleById,
+		},
+		"ReconcileMappingRuleById": Route{
+			strings.ToUpper("Post"),
+			"/v1/mapping-rules/{rule-id}/reconcile",
+			c.ReconcileMappingRuleById,
+		},
+	}
+}
+
+// DeleteMappingRuleById - Deletes a mapping rule
+func (c *MappingRuleAPIController) DeleteMappingRuleById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	ruleIdParam, err := parseNumericParameter[int64](
+		params["rule-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.DeleteMappingRuleById(r.Context(), ruleIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// GetMappingRuleById - Get mapping rule
+func (c *MappingRuleAPIController) GetMappingRuleById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	ruleIdParam, err := parseNumericParameter[int64](
+		params["rule-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetMappingRuleById(r.Context(), ruleIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// GetMappingRules - Get mapping rules
+func (c *MappingRuleAPIController) GetMappingRules(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var configIdParam int32
+	if query.Has("configId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("configId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		configIdParam = param
+	} else {
+	}
+	result, err := c.service.GetMappingRules(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// PostMappingRule - Creates a mapping rule
+func (c *MappingRuleAPIController) PostMappingRule(w http.ResponseWriter, r *http.Request) {
+	mappingRuleParam := MappingRule{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&mappingRuleParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertMappingRuleRequired(mappingRuleParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertMappingRuleConstraints(mappingRuleParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PostMappingRule(r.Context(), mappingRuleParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// PreviewMappingRule - Previews a mapping rule
+func (c *MappingRuleAPIController) PreviewMappingRule(w http.ResponseWriter, r *http.Request) {
+	mappingRuleParam := MappingRule{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&mappingRuleParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertMappingRuleRequired(mappingRuleParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertMappingRuleConstraints(mappingRuleParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PreviewMappingRule(r.Context(), mappingRuleParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// PutMappingRuleById - Updates a mapping rule
+func (c *MappingRuleAPIController) PutMappingRuleById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	ruleIdParam, err := parseNumericParameter[int64](
+		params["rule-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	mappingRuleParam := MappingRule{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&mappingRuleParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertMappingRuleRequired(mappingRuleParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertMappingRuleConstraints(mappingRuleParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PutMappingRuleById(r.Context(), ruleIdParam, mappingRuleParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
+
+// ReconcileMappingRuleById - Reconciles a mapping rule
+func (c *MappingRuleAPIController) ReconcileMappingRuleById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	ruleIdParam, err := parseNumericParameter[int64](
+		params["rule-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.ReconcileMappingRuleById(r.Context(), ruleIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, result.Headers, w)
+}
@@ -36,6 +36,30 @@ type AssetAttribute struct {
 
 	// The register reference in Zevvy (default register reference is the attribute name)
 	RegisterReference *string `json:"registerReference,omitempty"`
+
+	// Number of decimal places (0 to 15) the values are rounded to before they are sent to Zevvy. Use 0 for registers expecting integer values. If not set, values are sent with full precision.
+	Precision *int32 `json:"precision,omitempty"`
+
+	// Time of the last attempt to send data to Zevvy
+	LastAttemptTimestamp *time.Time `json:"lastAttemptTimestamp,omitempty"`
+
+	// Time of the last successful attempt to send data to Zevvy
+	LastSuccessTimestamp *time.Time `json:"lastSuccessTimestamp,omitempty"`
+
+	// Error of the last attempt to send data to Zevvy. Empty if the last attempt was successful.
+	LastError *string `json:"lastError,omitempty"`
+
+	// Number of failed attempts since the last successful attempt
+	ConsecutiveFailures *int32 `json:"consecutiveFailures,omitempty"`
+
+	// Total number of measurements sent to Zevvy
+	TotalSent *int64 `json:"totalSent,omitempty"`
+
+	// ID of the mapping rule that created the asset attribute
+	RuleId *int64 `json:"ruleId,omitempty"`
+
+	// Time the asset attribute was retired, because its asset is no longer selected by the mapping rule. Retired asset attributes are not sent to Zevvy until the asset is selected again.
+	RetiredTimestamp *time.Time `json:"retiredTimestamp,omitempty"`
 }
 
 // AssertAssetAttributeRequired checks if the required fields are not zero-ed
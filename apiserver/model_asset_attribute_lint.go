@@ -0,0 +1,62 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// AssetAttributeLint - A configured asset attribute that doesn't match the attribute schema in Eliona.
+type AssetAttributeLint struct {
+
+	// Id of the configuration the asset attribute belongs to
+	ConfigId int32 `json:"configId"`
+
+	// Id of the asset
+	AssetId int32 `json:"assetId"`
+
+	// Subtype of the asset attribute
+	Subtype string `json:"subtype"`
+
+	// Name of the asset attribute
+	AttributeName string `json:"attributeName"`
+
+	// Reasons why the asset attribute doesn't match
+	Errors []FieldError `json:"errors"`
+}
+
+// AssertAssetAttributeLintRequired checks if the required fields are not zero-ed
+func AssertAssetAttributeLintRequired(obj AssetAttributeLint) error {
+	elements := map[string]interface{}{
+		"configId":      obj.ConfigId,
+		"assetId":       obj.AssetId,
+		"subtype":       obj.Subtype,
+		"attributeName": obj.AttributeName,
+		"errors":        obj.Errors,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	for _, el := range obj.Errors {
+		if err := AssertFieldErrorRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertAssetAttributeLintConstraints checks if the values respects the defined constraints
+func AssertAssetAttributeLintConstraints(obj AssetAttributeLint) error {
+	for _, el := range obj.Errors {
+		if err := AssertFieldErrorConstraints(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
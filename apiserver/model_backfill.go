@@ -0,0 +1,74 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// Backfill - Sends the historical data of a configured asset attribute to Zevvy.
+type Backfill struct {
+
+	// Internal identifier for the backfill job (created automatically).
+	Id *int64 `json:"id,omitempty"`
+
+	// Config ID
+	ConfigId int32 `json:"configId"`
+
+	// Eliona asset ID. If not set when creating, jobs are created for all assets of the configuration.
+	AssetId *int32 `json:"assetId,omitempty"`
+
+	// Asset attribute subtype. If not set when creating, jobs are created for all subtypes.
+	Subtype *string `json:"subtype,omitempty"`
+
+	// Asset attribute name. If not set when creating, jobs are created for all attributes.
+	AttributeName *string `json:"attributeName,omitempty"`
+
+	// Data stored in Eliona from this timestamp on is queued for sending to Zevvy
+	StartTimestamp time.Time `json:"startTimestamp"`
+
+	// Data is queued up to this timestamp (exclusive). Set to the latest timestamp already read from Eliona when the job is created.
+	EndTimestamp *time.Time `json:"endTimestamp,omitempty"`
+
+	// All data before this timestamp was already queued for sending to Zevvy. Queued data is sent with the current data of the attribute.
+	ProgressTimestamp *time.Time `json:"progressTimestamp,omitempty"`
+
+	// Status of the backfill job. A finished job has queued all its data, which may still wait in the outbox to be sent.
+	Status *string `json:"status,omitempty"`
+
+	// Last error occurred while running the backfill job
+	Error *string `json:"error,omitempty"`
+
+	// Timestamp the backfill job was created
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Timestamp the backfill job was last updated
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// AssertBackfillRequired checks if the required fields are not zero-ed
+func AssertBackfillRequired(obj Backfill) error {
+	elements := map[string]interface{}{
+		"configId":       obj.ConfigId,
+		"startTimestamp": obj.StartTimestamp,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertBackfillConstraints checks if the values respects the defined constraints
+func AssertBackfillConstraints(obj Backfill) error {
+	return nil
+}
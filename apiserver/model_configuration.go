@@ -9,6 +9,10 @@
 
 package apiserver
 
+import (
+	"time"
+)
+
 // Configuration - Each configuration defines access to provider's API.
 type Configuration struct {
 
@@ -22,26 +26,38 @@ type Configuration struct {
 	ApiRootUrl string `json:"apiRootUrl,omitempty"`
 
 	// Client ID for API access
-	ClientId string `json:"clientId"`
+	ClientId string `json:"clientId,omitempty"`
 
 	// Set the client secret for API access
-	ClientSecret string `json:"clientSecret"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+
+	// How the app obtains tokens from Zevvy. `device_code` starts a login the user has to verify, `client_credentials` uses the client ID and secret only and `refresh_token` uses the given static refresh token. The last two need no user interaction.
+	AuthMode *string `json:"authMode,omitempty"`
 
 	// Login-URL to verify the access to the API
 	VerificationUri *string `json:"verificationUri,omitempty"`
 
+	// State of the login process. `pending` while waiting for the user to verify the login, `authorized` after a successful login and `denied` if the user denied the access or the static refresh token was rejected. A new login starts, when a denied configuration is updated.
+	LoginState *string `json:"loginState,omitempty"`
+
 	// Optionally set the refresh token. If not provided, it will be automatically assigned during the authentication login process managed by the app.
 	RefreshToken *string `json:"refreshToken,omitempty"`
 
+	// Expiry of the refresh token. After this time a new login is needed. Not set if the refresh token doesn't expire.
+	RefreshTokenExpire *time.Time `json:"refreshTokenExpire,omitempty"`
+
 	// Flag to enable or disable fetching from this API
 	Enable *bool `json:"enable,omitempty"`
 
 	// Interval in seconds for collecting data from API
-	RefreshInterval int32 `json:"refreshInterval,omitempty"`
+	RefreshInterval *int32 `json:"refreshInterval,omitempty"`
 
 	// Timeout in seconds
 	RequestTimeout *int32 `json:"requestTimeout,omitempty"`
 
+	// Maximum number of measurements sent to Zevvy in one request
+	BatchSize *int32 `json:"batchSize,omitempty"`
+
 	// Set to `true` by the app when running and to `false` when app is stopped
 	Active *bool `json:"active,omitempty"`
 
@@ -50,20 +66,19 @@ type Configuration struct {
 
 	// ID of the project the Eliona user created or updated the configuration
 	ProjectId *string `json:"projectId,omitempty"`
+
+	// Template for the device references of asset attributes without a given device reference. Defaults to `{gai}`.
+	DeviceReferenceTemplate *string `json:"deviceReferenceTemplate,omitempty"`
+
+	// Template for the register references of asset attributes without a given register reference. Defaults to `{attribute}`.
+	RegisterReferenceTemplate *string `json:"registerReferenceTemplate,omitempty"`
+
+	// Version of the configuration, increased with each update. Returned as `ETag` header to be used in `If-Match` headers.
+	Version *int32 `json:"version,omitempty"`
 }
 
 // AssertConfigurationRequired checks if the required fields are not zero-ed
 func AssertConfigurationRequired(obj Configuration) error {
-	elements := map[string]interface{}{
-		"clientId":     obj.ClientId,
-		"clientSecret": obj.ClientSecret,
-	}
-	for name, el := range elements {
-		if isZero := IsZeroValue(el); isZero {
-			return &RequiredError{Field: name}
-		}
-	}
-
 	return nil
 }
 
@@ -0,0 +1,67 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// DeadLetter - Measurement rejected by Zevvy.
+type DeadLetter struct {
+
+	// Internal identifier for the dead letter
+	Id *int64 `json:"id,omitempty"`
+
+	// Config ID
+	ConfigId int32 `json:"configId,omitempty"`
+
+	// Eliona asset ID
+	AssetId int32 `json:"assetId,omitempty"`
+
+	// Asset attribute subtype
+	Subtype string `json:"subtype,omitempty"`
+
+	// Asset attribute name
+	AttributeName string `json:"attributeName,omitempty"`
+
+	// The device reference the measurement was sent to
+	DeviceReference string `json:"deviceReference,omitempty"`
+
+	// The register reference the measurement was sent to
+	RegisterReference string `json:"registerReference,omitempty"`
+
+	// Timestamp of the measurement
+	ReadAt *time.Time `json:"readAt,omitempty"`
+
+	// Value of the measurement
+	Value float64 `json:"value,omitempty"`
+
+	// HTTP status code returned by Zevvy
+	StatusCode *int32 `json:"statusCode,omitempty"`
+
+	// Reason the measurement was rejected
+	Error string `json:"error,omitempty"`
+
+	// Response body returned by Zevvy
+	ResponseBody *string `json:"responseBody,omitempty"`
+
+	// Timestamp the measurement was rejected
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// AssertDeadLetterRequired checks if the required fields are not zero-ed
+func AssertDeadLetterRequired(obj DeadLetter) error {
+	return nil
+}
+
+// AssertDeadLetterConstraints checks if the values respects the defined constraints
+func AssertDeadLetterConstraints(obj DeadLetter) error {
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// DeletionPreview - Lists the data affected by deleting a configuration.
+type DeletionPreview struct {
+
+	// Mapping policy applied to the asset attributes of the configuration
+	Mappings string `json:"mappings,omitempty"`
+
+	// Id of the configuration the asset attributes are reassigned to
+	ReassignTo *int64 `json:"reassignTo,omitempty"`
+
+	// Asset attributes mapped by the configuration
+	AssetAttributes []AssetAttribute `json:"assetAttributes,omitempty"`
+
+	// Asset attributes already mapped by the target configuration or whose device and register references are already used there. Reassigning is only possible without conflicts.
+	Conflicts []AssetAttribute `json:"conflicts,omitempty"`
+
+	// Number of mapping rules of the configuration
+	MappingRules int32 `json:"mappingRules,omitempty"`
+
+	// Number of queued measurements of the configuration
+	Outbox int32 `json:"outbox,omitempty"`
+
+	// Number of backfill jobs of the configuration
+	Backfills int32 `json:"backfills,omitempty"`
+
+	// Number of dead letters of the configuration
+	DeadLetters int32 `json:"deadLetters,omitempty"`
+}
+
+// AssertDeletionPreviewRequired checks if the required fields are not zero-ed
+func AssertDeletionPreviewRequired(obj DeletionPreview) error {
+	for _, el := range obj.AssetAttributes {
+		if err := AssertAssetAttributeRequired(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.Conflicts {
+		if err := AssertAssetAttributeRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertDeletionPreviewConstraints checks if the values respects the defined constraints
+func AssertDeletionPreviewConstraints(obj DeletionPreview) error {
+	for _, el := range obj.AssetAttributes {
+		if err := AssertAssetAttributeConstraints(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.Conflicts {
+		if err := AssertAssetAttributeConstraints(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// FieldError - Describes why the value of a field is invalid.
+type FieldError struct {
+
+	// Name of the invalid field
+	Field string `json:"field"`
+
+	// Why the value is invalid
+	Message string `json:"message"`
+}
+
+// AssertFieldErrorRequired checks if the required fields are not zero-ed
+func AssertFieldErrorRequired(obj FieldError) error {
+	elements := map[string]interface{}{
+		"field":   obj.Field,
+		"message": obj.Message,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertFieldErrorConstraints checks if the values respects the defined constraints
+func AssertFieldErrorConstraints(obj FieldError) error {
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// MappingRule - Maps the attribute of all assets selected by the rule to Zevvy.
+type MappingRule struct {
+
+	// Internal identifier for the mapping rule (created automatically).
+	Id *int64 `json:"id,omitempty"`
+
+	// Config ID
+	ConfigId int32 `json:"configId"`
+
+	// If set, only assets of this asset type are mapped
+	AssetType *string `json:"assetType,omitempty"`
+
+	// If set, only the children of this asset are mapped
+	ParentAssetId *int32 `json:"parentAssetId,omitempty"`
+
+	// If set, all assets below the parent asset are mapped instead of its children only
+	IncludeDescendants *bool `json:"includeDescendants,omitempty"`
+
+	// If set, only assets of this project are mapped
+	ProjectId *string `json:"projectId,omitempty"`
+
+	// If set, only assets whose tags match the expression are mapped. Tags are combined with & (and), | (or) and ! (not) and grouped with parentheses.
+	TagExpression *string `json:"tagExpression,omitempty"`
+
+	// Subtype of the mapped attribute
+	Subtype string `json:"subtype"`
+
+	// Name of the mapped attribute
+	AttributeName string `json:"attributeName"`
+
+	// Number of decimal places (0 to 15) the values are rounded to. If not set, values are sent with full precision.
+	Precision *int32 `json:"precision,omitempty"`
+
+	// Flag to enable or disable the mapping rule
+	Enable *bool `json:"enable,omitempty"`
+
+	// Number of asset attributes currently mapped by the rule
+	Mappings *int32 `json:"mappings,omitempty"`
+
+	// Timestamp the rule was last reconciled
+	LastReconcileTimestamp *time.Time `json:"lastReconcileTimestamp,omitempty"`
+
+	// Error occurred while last reconciling the rule
+	LastError *string `json:"lastError,omitempty"`
+
+	// Timestamp the mapping rule was created
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// AssertMappingRuleRequired checks if the required fields are not zero-ed
+func AssertMappingRuleRequired(obj MappingRule) error {
+	elements := map[string]interface{}{
+		"configId":      obj.ConfigId,
+		"subtype":       obj.Subtype,
+		"attributeName": obj.AttributeName,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertMappingRuleConstraints checks if the values respects the defined constraints
+func AssertMappingRuleConstraints(obj MappingRule) error {
+	return nil
+}
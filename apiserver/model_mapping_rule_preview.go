@@ -0,0 +1,71 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// MappingRulePreview - Lists the asset attributes selected by a mapping rule.
+type MappingRulePreview struct {
+
+	// Asset attributes the rule would create
+	AssetAttributes []AssetAttribute `json:"assetAttributes,omitempty"`
+
+	// Asset attributes selected by the rule, but already configured manually or by another rule
+	AlreadyConfigured []AssetAttribute `json:"alreadyConfigured,omitempty"`
+
+	// Asset attributes the rule would skip, because their references are already used by other asset attributes
+	Collisions []ReferenceCollision `json:"collisions,omitempty"`
+
+	// Assets the rule would skip, because the references of their asset attributes couldn't be rendered
+	Skipped []SkippedAsset `json:"skipped,omitempty"`
+}
+
+// AssertMappingRulePreviewRequired checks if the required fields are not zero-ed
+func AssertMappingRulePreviewRequired(obj MappingRulePreview) error {
+	for _, el := range obj.AssetAttributes {
+		if err := AssertAssetAttributeRequired(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.AlreadyConfigured {
+		if err := AssertAssetAttributeRequired(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.Collisions {
+		if err := AssertReferenceCollisionRequired(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.Skipped {
+		if err := AssertSkippedAssetRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertMappingRulePreviewConstraints checks if the values respects the defined constraints
+func AssertMappingRulePreviewConstraints(obj MappingRulePreview) error {
+	for _, el := range obj.AssetAttributes {
+		if err := AssertAssetAttributeConstraints(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.AlreadyConfigured {
+		if err := AssertAssetAttributeConstraints(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.Collisions {
+		if err := AssertReferenceCollisionConstraints(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// ReconcileResult - Result of reconciling a mapping rule.
+type ReconcileResult struct {
+
+	// Number of asset attributes created for newly matching assets
+	Created int32 `json:"created,omitempty"`
+
+	// Number of asset attributes retired for assets no longer matching
+	Retired int32 `json:"retired,omitempty"`
+
+	// Number of retired asset attributes reactivated for assets matching again
+	Reactivated int32 `json:"reactivated,omitempty"`
+
+	// Number of asset attributes deleted together with their queued measurements, dead letters and backfills, because their assets were deleted in Eliona
+	Deleted int32 `json:"deleted,omitempty"`
+
+	// Asset attributes not created, because their references are already used by other asset attributes
+	Collisions []ReferenceCollision `json:"collisions,omitempty"`
+
+	// Assets skipped, because the references of their asset attributes couldn't be rendered. Their asset attributes are left untouched.
+	Skipped []SkippedAsset `json:"skipped,omitempty"`
+}
+
+// AssertReconcileResultRequired checks if the required fields are not zero-ed
+func AssertReconcileResultRequired(obj ReconcileResult) error {
+	return nil
+}
+
+// AssertReconcileResultConstraints checks if the values respects the defined constraints
+func AssertReconcileResultConstraints(obj ReconcileResult) error {
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// ReferenceCollision - Asset attributes of a configuration sharing the same device and register reference. Their measurements would be merged into one register in Zevvy.
+type ReferenceCollision struct {
+
+	// Id of the configuration the asset attributes belong to
+	ConfigId int64 `json:"configId"`
+
+	// Device reference used by all asset attributes
+	DeviceReference string `json:"deviceReference"`
+
+	// Register reference used by all asset attributes
+	RegisterReference string `json:"registerReference"`
+
+	// Asset attributes using the references
+	AssetAttributes []AssetAttribute `json:"assetAttributes"`
+}
+
+// AssertReferenceCollisionRequired checks if the required fields are not zero-ed
+func AssertReferenceCollisionRequired(obj ReferenceCollision) error {
+	elements := map[string]interface{}{
+		"configId":          obj.ConfigId,
+		"deviceReference":   obj.DeviceReference,
+		"registerReference": obj.RegisterReference,
+		"assetAttributes":   obj.AssetAttributes,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	for _, el := range obj.AssetAttributes {
+		if err := AssertAssetAttributeRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertReferenceCollisionConstraints checks if the values respects the defined constraints
+func AssertReferenceCollisionConstraints(obj ReferenceCollision) error {
+	for _, el := range obj.AssetAttributes {
+		if err := AssertAssetAttributeConstraints(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
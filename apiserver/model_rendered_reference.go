@@ -0,0 +1,71 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// RenderedReference - The references of a configured asset attribute rendered again with the templates of the configuration.
+type RenderedReference struct {
+
+	// Id of the asset
+	AssetId int32 `json:"assetId"`
+
+	// Subtype of the asset attribute
+	Subtype string `json:"subtype"`
+
+	// Name of the asset attribute
+	AttributeName string `json:"attributeName"`
+
+	// Device reference rendered with the current template
+	DeviceReference *string `json:"deviceReference,omitempty"`
+
+	// Register reference rendered with the current template
+	RegisterReference *string `json:"registerReference,omitempty"`
+
+	// Device reference before rendering
+	PreviousDeviceReference string `json:"previousDeviceReference"`
+
+	// Register reference before rendering
+	PreviousRegisterReference string `json:"previousRegisterReference"`
+
+	// Reasons why the references couldn't be rendered
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// AssertRenderedReferenceRequired checks if the required fields are not zero-ed
+func AssertRenderedReferenceRequired(obj RenderedReference) error {
+	elements := map[string]interface{}{
+		"assetId":                   obj.AssetId,
+		"subtype":                   obj.Subtype,
+		"attributeName":             obj.AttributeName,
+		"previousDeviceReference":   obj.PreviousDeviceReference,
+		"previousRegisterReference": obj.PreviousRegisterReference,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	for _, el := range obj.Errors {
+		if err := AssertFieldErrorRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRenderedReferenceConstraints checks if the values respects the defined constraints
+func AssertRenderedReferenceConstraints(obj RenderedReference) error {
+	for _, el := range obj.Errors {
+		if err := AssertFieldErrorConstraints(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
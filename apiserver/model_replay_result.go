@@ -0,0 +1,27 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// ReplayResult - Result of replaying dead letters.
+type ReplayResult struct {
+
+	// Number of dead letters moved back to the outbox
+	Replayed int32 `json:"replayed,omitempty"`
+}
+
+// AssertReplayResultRequired checks if the required fields are not zero-ed
+func AssertReplayResultRequired(obj ReplayResult) error {
+	return nil
+}
+
+// AssertReplayResultConstraints checks if the values respects the defined constraints
+func AssertReplayResultConstraints(obj ReplayResult) error {
+	return nil
+}
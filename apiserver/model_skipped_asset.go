@@ -0,0 +1,40 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// SkippedAsset - Asset selected by a mapping rule, but skipped, because the references of its asset attribute couldn't be rendered.
+type SkippedAsset struct {
+
+	// Eliona asset ID
+	AssetId int32 `json:"assetId"`
+
+	// Why the references couldn't be rendered
+	Message string `json:"message"`
+}
+
+// AssertSkippedAssetRequired checks if the required fields are not zero-ed
+func AssertSkippedAssetRequired(obj SkippedAsset) error {
+	elements := map[string]interface{}{
+		"assetId": obj.AssetId,
+		"message": obj.Message,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertSkippedAssetConstraints checks if the values respects the defined constraints
+func AssertSkippedAssetConstraints(obj SkippedAsset) error {
+	return nil
+}
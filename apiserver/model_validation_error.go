@@ -0,0 +1,49 @@
+/*
+ * Zevvy app API
+ *
+ * API to access and configure the Zevvy app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// ValidationError - Lists the invalid fields of a request.
+type ValidationError struct {
+
+	// Summary of the validation errors
+	Message string `json:"message"`
+
+	// Errors per invalid field
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// AssertValidationErrorRequired checks if the required fields are not zero-ed
+func AssertValidationErrorRequired(obj ValidationError) error {
+	elements := map[string]interface{}{
+		"message": obj.Message,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	for _, el := range obj.Errors {
+		if err := AssertFieldErrorRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertValidationErrorConstraints checks if the values respects the defined constraints
+func AssertValidationErrorConstraints(obj ValidationError) error {
+	for _, el := range obj.Errors {
+		if err := AssertFieldErrorConstraints(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,7 +7,6 @@ package apiservices
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"zevvy/apiserver"
 	"zevvy/conf"
@@ -45,11 +44,26 @@ func (s *AssetAttributeAPIService) GetAssetAttributes(ctx context.Context, confi
 // PutAssetAttribute - Creates or updates a configured asset attribute
 func (s *AssetAttributeAPIService) PutAssetAttribute(ctx context.Context, assetAttribute apiserver.AssetAttribute) (apiserver.ImplResponse, error) {
 	upserted, err := conf.UpsertAssetAttribute(ctx, &assetAttribute)
-	if errors.Is(err, conf.ErrNotFound) {
-		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	if err != nil {
+		return mappingErrorResponse(err)
 	}
+	return apiserver.Response(http.StatusOK, upserted), nil
+}
+
+// LintAssetAttributes - Checks configured asset attributes against Eliona
+func (s *AssetAttributeAPIService) LintAssetAttributes(ctx context.Context, configId int32, assetId int32, subtype string, attributeName string) (apiserver.ImplResponse, error) {
+	lints, err := conf.LintAssetAttributes(ctx, configId, assetId, subtype, attributeName)
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
-	return apiserver.Response(http.StatusOK, upserted), nil
+	return apiserver.Response(http.StatusOK, lints), nil
+}
+
+// GetReferenceCollisions - Lists asset attributes with colliding references
+func (s *AssetAttributeAPIService) GetReferenceCollisions(ctx context.Context, configId int32) (apiserver.ImplResponse, error) {
+	collisions, err := conf.GetReferenceCollisions(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, collisions), nil
 }
@@ -0,0 +1,70 @@
+/*
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"zevvy/apiserver"
+	"zevvy/conf"
+)
+
+// BackfillAPIService is a service that implements the logic for the BackfillAPIServicer
+// This service should implement the business logic for every endpoint for the BackfillAPI API.
+// Include any external packages or services that will be required by this service.
+type BackfillAPIService struct {
+}
+
+// NewBackfillAPIService creates a default api service
+func NewBackfillAPIService() apiserver.BackfillAPIServicer {
+	return &BackfillAPIService{}
+}
+
+// DeleteBackfillById - Deletes a backfill job
+func (s *BackfillAPIService) DeleteBackfillById(ctx context.Context, backfillId int64) (apiserver.ImplResponse, error) {
+	err := conf.DeleteBackfill(ctx, backfillId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+// GetBackfillById - Get backfill job
+func (s *BackfillAPIService) GetBackfillById(ctx context.Context, backfillId int64) (apiserver.ImplResponse, error) {
+	backfill, err := conf.GetBackfill(ctx, backfillId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, backfill), nil
+}
+
+// GetBackfills - Get backfill jobs
+func (s *BackfillAPIService) GetBackfills(ctx context.Context, configId int32) (apiserver.ImplResponse, error) {
+	backfills, err := conf.GetBackfills(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, backfills), nil
+}
+
+// PostBackfill - Creates backfill jobs
+func (s *BackfillAPIService) PostBackfill(ctx context.Context, backfill apiserver.Backfill) (apiserver.ImplResponse, error) {
+	backfills, err := conf.CreateBackfills(ctx, backfill)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusCreated, backfills), nil
+}
@@ -18,9 +18,11 @@ package apiservices
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"zevvy/apiserver"
 	"zevvy/conf"
+	"zevvy/zevvy"
 )
 
 // ConfigurationAPIService is a service that implements the logic for the ConfigurationAPIServicer
@@ -42,41 +44,131 @@ func (s *ConfigurationAPIService) GetConfigurations(ctx context.Context) (apiser
 	return apiserver.Response(http.StatusOK, configs), nil
 }
 
-func (s *ConfigurationAPIService) PostConfiguration(ctx context.Context, config apiserver.Configuration) (apiserver.ImplResponse, error) {
+func (s *ConfigurationAPIService) PostConfiguration(ctx context.Context, verify bool, config apiserver.Configuration) (apiserver.ImplResponse, error) {
+	if err := validateConfig(config, verify); err != nil {
+		return configErrorResponse(err)
+	}
 	insertedConfig, err := conf.InsertConfig(ctx, config)
 	if err != nil {
-		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		return configErrorResponse(err)
 	}
-	return apiserver.Response(http.StatusCreated, insertedConfig), nil
+	headers := etagHeader(insertedConfig)
+	headers["Location"] = []string{fmt.Sprintf("/v1/configs/%d", *insertedConfig.Id)}
+	return apiserver.ResponseWithHeaders(http.StatusCreated, headers, insertedConfig), nil
 }
 
 func (s *ConfigurationAPIService) GetConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
 	config, err := conf.GetConfig(ctx, configId)
-	if errors.Is(err, conf.ErrNotFound) {
-		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
-	}
 	if err != nil {
-		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		return configErrorResponse(err)
 	}
-	return apiserver.Response(http.StatusOK, config), nil
+	return apiserver.ResponseWithHeaders(http.StatusOK, etagHeader(config), config), nil
 }
 
-func (s *ConfigurationAPIService) PutConfigurationById(ctx context.Context, configId int64, config apiserver.Configuration) (apiserver.ImplResponse, error) {
+func (s *ConfigurationAPIService) PutConfigurationById(ctx context.Context, configId int64, verify bool, ifMatch string, config apiserver.Configuration) (apiserver.ImplResponse, error) {
+	if err := validateConfig(config, verify); err != nil {
+		return configErrorResponse(err)
+	}
 	config.Id = &configId
-	upsertedConfig, err := conf.UpsertConfig(ctx, config)
+	upsertedConfig, created, err := conf.UpsertConfig(ctx, config, ifMatch)
 	if err != nil {
-		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		return configErrorResponse(err)
+	}
+	if created {
+		return apiserver.ResponseWithHeaders(http.StatusCreated, etagHeader(upsertedConfig), upsertedConfig), nil
 	}
-	return apiserver.Response(http.StatusCreated, upsertedConfig), nil
+	return apiserver.ResponseWithHeaders(http.StatusOK, etagHeader(upsertedConfig), upsertedConfig), nil
 }
 
-func (s *ConfigurationAPIService) DeleteConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
-	err := conf.DeleteConfig(ctx, configId)
-	if errors.Is(err, conf.ErrNotFound) {
-		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+func (s *ConfigurationAPIService) PatchConfigurationById(ctx context.Context, configId int64, verify bool, ifMatch string, patch apiserver.Configuration) (apiserver.ImplResponse, error) {
+	config, err := conf.GetConfig(ctx, configId)
+	if err != nil {
+		return configErrorResponse(err)
+	}
+	// Without If-Match the patch is applied to the version read, so concurrent updates aren't lost.
+	if ifMatch == "" {
+		ifMatch = conf.ETag(*config.Version)
+	}
+	config = conf.MergeConfig(config, patch)
+	if err := validateConfig(config, verify); err != nil {
+		return configErrorResponse(err)
 	}
+	updatedConfig, err := conf.UpdateConfig(ctx, config, ifMatch)
 	if err != nil {
-		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		return configErrorResponse(err)
+	}
+	return apiserver.ResponseWithHeaders(http.StatusOK, etagHeader(updatedConfig), updatedConfig), nil
+}
+
+func (s *ConfigurationAPIService) DeleteConfigurationById(ctx context.Context, configId int64, mappings string, reassignTo int64, dryRun bool) (apiserver.ImplResponse, error) {
+	preview, err := conf.DeleteConfig(ctx, configId, mappings, reassignTo, dryRun)
+	if errors.Is(err, conf.ErrConflict) {
+		return apiserver.Response(http.StatusConflict, preview), nil
+	}
+	if err != nil {
+		return configErrorResponse(err)
+	}
+	if dryRun {
+		return apiserver.Response(http.StatusOK, preview), nil
 	}
 	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
 }
+
+// RenderConfigurationReferences - Renders the references of a configuration again
+func (s *ConfigurationAPIService) RenderConfigurationReferences(ctx context.Context, configId int64, dryRun bool) (apiserver.ImplResponse, error) {
+	renderedReferences, err := conf.RenderReferences(ctx, configId, dryRun)
+	if err != nil {
+		return configErrorResponse(err)
+	}
+	return apiserver.Response(http.StatusOK, renderedReferences), nil
+}
+
+// validateConfig checks the fields of the configuration. If verify is set, the OpenID discovery
+// document and the API root are requested, too.
+func validateConfig(config apiserver.Configuration, verify bool) error {
+	if err := conf.ValidateConfig(config); err != nil || !verify {
+		return err
+	}
+	validationErr := &conf.ValidationError{}
+	if err := zevvy.CheckDiscovery(config.AuthRootUrl); err != nil {
+		validationErr.Add("authRootUrl", "has no valid OpenID discovery document: %v", err)
+	}
+	if err := zevvy.CheckApiRoot(config.ApiRootUrl); err != nil {
+		validationErr.Add("apiRootUrl", "is not reachable: %v", err)
+	}
+	return validationErr.OrNil()
+}
+
+// etagHeader returns the ETag header of the configuration.
+func etagHeader(config apiserver.Configuration) map[string][]string {
+	headers := map[string][]string{}
+	if config.Version != nil {
+		headers["ETag"] = []string{conf.ETag(*config.Version)}
+	}
+	return headers
+}
+
+// configErrorResponse maps the errors of the conf package to the status codes of the API. Invalid
+// fields are returned with status 400.
+func configErrorResponse(err error) (apiserver.ImplResponse, error) {
+	var validationErr *conf.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return apiserver.Response(http.StatusBadRequest, validationErrorBody(validationErr)), nil
+	case errors.Is(err, conf.ErrNotFound):
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	case errors.Is(err, conf.ErrPreconditionFailed):
+		return apiserver.ImplResponse{Code: http.StatusPreconditionFailed}, nil
+	default:
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+}
+
+// validationErrorBody returns the invalid fields in the response body of the API.
+func validationErrorBody(validationErr *conf.ValidationError) apiserver.ValidationError {
+	body := apiserver.ValidationError{Message: validationErr.Error()}
+	for _, field := range validationErr.Fields {
+		body.Errors = append(body.Errors, apiserver.FieldError{Field: field.Field, Message: field.Message})
+	}
+	return body
+}
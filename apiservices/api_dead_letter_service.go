@@ -0,0 +1,88 @@
+/*
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"zevvy/apiserver"
+	"zevvy/conf"
+)
+
+// DeadLetterAPIService is a service that implements the logic for the DeadLetterAPIServicer
+// This service should implement the business logic for every endpoint for the DeadLetterAPI API.
+// Include any external packages or services that will be required by this service.
+type DeadLetterAPIService struct {
+}
+
+// NewDeadLetterAPIService creates a default api service
+func NewDeadLetterAPIService() apiserver.DeadLetterAPIServicer {
+	return &DeadLetterAPIService{}
+}
+
+// DeleteDeadLetterById - Discards a dead letter
+func (s *DeadLetterAPIService) DeleteDeadLetterById(ctx context.Context, deadLetterId int64) (apiserver.ImplResponse, error) {
+	err := conf.DeleteDeadLetter(ctx, deadLetterId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+// DeleteDeadLetters - Discards dead letters
+func (s *DeadLetterAPIService) DeleteDeadLetters(ctx context.Context, configId int32, assetId int32, subtype string, attributeName string) (apiserver.ImplResponse, error) {
+	err := conf.DeleteDeadLetters(ctx, configId, assetId, subtype, attributeName)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+// GetDeadLetterById - Get dead letter
+func (s *DeadLetterAPIService) GetDeadLetterById(ctx context.Context, deadLetterId int64) (apiserver.ImplResponse, error) {
+	deadLetter, err := conf.GetDeadLetter(ctx, deadLetterId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, deadLetter), nil
+}
+
+// GetDeadLetters - Get dead letters
+func (s *DeadLetterAPIService) GetDeadLetters(ctx context.Context, configId int32, assetId int32, subtype string, attributeName string) (apiserver.ImplResponse, error) {
+	deadLetters, err := conf.GetDeadLetters(ctx, configId, assetId, subtype, attributeName)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, deadLetters), nil
+}
+
+// ReplayDeadLetterById - Replays a dead letter
+func (s *DeadLetterAPIService) ReplayDeadLetterById(ctx context.Context, deadLetterId int64) (apiserver.ImplResponse, error) {
+	err := conf.ReplayDeadLetter(ctx, deadLetterId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+// ReplayDeadLetters - Replays dead letters
+func (s *DeadLetterAPIService) ReplayDeadLetters(ctx context.Context, configId int32, assetId int32, subtype string, attributeName string) (apiserver.ImplResponse, error) {
+	replayed, err := conf.ReplayDeadLetters(ctx, configId, assetId, subtype, attributeName)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, apiserver.ReplayResult{Replayed: int32(replayed)}), nil
+}
@@ -0,0 +1,118 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"zevvy/apiserver"
+	"zevvy/conf"
+)
+
+// MappingRuleAPIService is a service that implements the logic for the MappingRuleAPIServicer
+// This service should implement the business logic for every endpoint for the MappingRuleAPI API.
+// Include any external packages or services that will be required by this service.
+type MappingRuleAPIService struct {
+}
+
+// NewMappingRuleAPIService creates a default api service
+func NewMappingRuleAPIService() apiserver.MappingRuleAPIServicer {
+	return &MappingRuleAPIService{}
+}
+
+// DeleteMappingRuleById - Deletes a mapping rule
+func (s *MappingRuleAPIService) DeleteMappingRuleById(ctx context.Context, ruleId int64) (apiserver.ImplResponse, error) {
+	err := conf.DeleteMappingRule(ctx, ruleId)
+	if err != nil {
+		return mappingErrorResponse(err)
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
+
+// GetMappingRuleById - Get mapping rule
+func (s *MappingRuleAPIService) GetMappingRuleById(ctx context.Context, ruleId int64) (apiserver.ImplResponse, error) {
+	rule, err := conf.GetMappingRule(ctx, ruleId)
+	if err != nil {
+		return mappingErrorResponse(err)
+	}
+	return apiserver.Response(http.StatusOK, rule), nil
+}
+
+// GetMappingRules - Get mapping rules
+func (s *MappingRuleAPIService) GetMappingRules(ctx context.Context, configId int32) (apiserver.ImplResponse, error) {
+	rules, err := conf.GetMappingRules(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, rules), nil
+}
+
+// PostMappingRule - Creates a mapping rule
+func (s *MappingRuleAPIService) PostMappingRule(ctx context.Context, mappingRule apiserver.MappingRule) (apiserver.ImplResponse, error) {
+	rule, err := conf.CreateMappingRule(ctx, mappingRule)
+	if err != nil {
+		return mappingErrorResponse(err)
+	}
+	return apiserver.Response(http.StatusCreated, rule), nil
+}
+
+// PreviewMappingRule - Previews a mapping rule
+func (s *MappingRuleAPIService) PreviewMappingRule(ctx context.Context, mappingRule apiserver.MappingRule) (apiserver.ImplResponse, error) {
+	preview, err := conf.PreviewMappingRule(ctx, mappingRule)
+	if err != nil {
+		return mappingErrorResponse(err)
+	}
+	return apiserver.Response(http.StatusOK, preview), nil
+}
+
+// PutMappingRuleById - Updates a mapping rule
+func (s *MappingRuleAPIService) PutMappingRuleById(ctx context.Context, ruleId int64, mappingRule apiserver.MappingRule) (apiserver.ImplResponse, error) {
+	rule, err := conf.UpdateMappingRule(ctx, ruleId, mappingRule)
+	if err != nil {
+		return mappingErrorResponse(err)
+	}
+	return apiserver.Response(http.StatusOK, rule), nil
+}
+
+// ReconcileMappingRuleById - Reconciles a mapping rule
+func (s *MappingRuleAPIService) ReconcileMappingRuleById(ctx context.Context, ruleId int64) (apiserver.ImplResponse, error) {
+	result, err := conf.ReconcileMappingRuleById(ctx, ruleId)
+	if err != nil {
+		return mappingErrorResponse(err)
+	}
+	return apiserver.Response(http.StatusOK, result), nil
+}
+
+// mappingErrorResponse maps the errors of the conf package to the status codes of the API. Mappings not
+// matching the configuration or the attribute schemas in Eliona are returned with status 422, mappings
+// whose references are already used by other asset attributes with status 409.
+func mappingErrorResponse(err error) (apiserver.ImplResponse, error) {
+	var validationErr *conf.ValidationError
+	var collisionErr *conf.ReferenceCollisionError
+	switch {
+	case errors.As(err, &validationErr):
+		return apiserver.Response(http.StatusUnprocessableEntity, validationErrorBody(validationErr)), nil
+	case errors.As(err, &collisionErr):
+		body := validationErrorBody(collisionErr.ValidationError())
+		body.Message = collisionErr.Error()
+		return apiserver.Response(http.StatusConflict, body), nil
+	case errors.Is(err, conf.ErrNotFound):
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	default:
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+}
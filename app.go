@@ -17,6 +17,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
@@ -27,6 +28,8 @@ import (
 	"github.com/eliona-smart-building-assistant/go-utils/db"
 	utilshttp "github.com/eliona-smart-building-assistant/go-utils/http"
 	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
+	"math"
 	"net/http"
 	"sync"
 	"time"
@@ -35,6 +38,8 @@ import (
 	"zevvy/appdb"
 	"zevvy/conf"
 	"zevvy/eliona"
+	"zevvy/health"
+	"zevvy/metrics"
 	"zevvy/model"
 	"zevvy/zevvy"
 )
@@ -52,6 +57,16 @@ func initialization() {
 		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
 		dashboard.InitWidgetTypeFiles("resources/widget-types/*.json"),
 	)
+
+	// Patch the app to the current version.
+	app.Patch(conn, app.AppName(), "010100",
+		app.ExecSqlFile("conf/v1.1.0.sql"),
+	)
+
+	// Installations patched with colliding references get the constraint once the collisions are resolved.
+	if err := conf.AddReferenceConstraint(ctx); err != nil {
+		log.Error("conf", "Cannot add reference constraint: %v", err)
+	}
 }
 
 var once sync.Once
@@ -85,8 +100,7 @@ func sendData() {
 			// Check for Login process
 			if conf.IsLoginNeeded(dbConfig) {
 				common.RunOnceWithParam(func(config appdb.Configuration) {
-					startLoginProcess(&config)
-					time.Sleep(time.Second * time.Duration(config.VerificationInterval.Int32))
+					startLoginProcess(&config, false)
 				}, *dbConfig, dbConfig.ID)
 				continue
 			}
@@ -95,13 +109,30 @@ func sendData() {
 			common.RunOnceWithParam(func(config appdb.Configuration) {
 				log.Info("main", "Collecting %d started.", config.ID)
 
-				// Check for valid access token
-				if !conf.IsAccessTokenIsValid(&config) {
+				// Start a new login once the offline access is lost
+				if conf.IsRefreshTokenExpired(&config) {
+					restartLogin(&config)
+					return
+				}
+
+				// Refresh the access token ahead of its expiry
+				if conf.IsAccessTokenRefreshNeeded(&config) {
 					refreshTokens(&config)
+					if conf.IsLoginNeeded(&config) {
+						return // A new login was started, because the refresh token was rejected.
+					}
+				}
+
+				// Warn the user before the offline access is lost
+				if conf.IsRefreshTokenExpiring(&config) {
+					notifyRefreshTokenExpiring(&config)
 				}
 
 				// Send data to Zevvy
-				if err := collectData(&config); err != nil {
+				start := time.Now()
+				err := collectData(&config)
+				metrics.SyncDuration(config.ID, time.Since(start))
+				if err != nil {
 					return // Error is handled in the method itself.
 				}
 
@@ -122,93 +153,224 @@ func collectData(dbConfig *appdb.Configuration) error {
 		return err
 	}
 
+	// Each attribute is processed on its own, so a failing attribute doesn't block the others.
 	for _, dbAssetAttribute := range dbAssetAttributes {
-
-		apiDataList, err := eliona.GetDataList(dbAssetAttribute)
-		if err != nil {
-			log.Error("Eliona", "Cannot get asset attributes: %v", err)
-			return err
+		if !attributeBackoffs.isDue(dbConfig, dbAssetAttribute) {
+			continue
 		}
-
-		if len(apiDataList) > 0 {
-			log.Debug("main", "Sending for attribute %d %s %s.", dbAssetAttribute.AssetID, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName)
+		if err := collectAttributeData(ctx, dbConfig, dbAssetAttribute); err != nil {
+			if err := conf.UpdateAssetAttributeFailure(ctx, dbAssetAttribute, err); err != nil {
+				log.Error("conf", "Cannot update status of attribute: %v", err)
+			}
+			delay := attributeBackoffs.failed(dbConfig, dbAssetAttribute, err)
+			log.Error("main", "Sending for attribute %d %s %s failed, next attempt in %v: %v", dbAssetAttribute.AssetID, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName, delay, err)
+			continue
+		}
+		attributeBackoffs.succeeded(dbAssetAttribute)
+		if err := conf.UpdateAssetAttributeSuccess(ctx, dbAssetAttribute); err != nil {
+			log.Error("conf", "Cannot update status of attribute: %v", err)
 		}
+	}
+
+	return nil
+}
 
-		// convert trend data to measurements
-		var measurements []model.Measurement
-		var latestTimestamp = dbAssetAttribute.LatestTS
-		for _, apiData := range apiDataList {
-			if apiData.Timestamp.IsSet() {
-				timestamp := common.Val(apiData.Timestamp.Get())
+// trendWindow limits the time range of data read from Eliona for an attribute in one cycle. An attribute
+// lagging behind, e.g. after an outage, catches up window by window like a backfill.
+const trendWindow = 24 * time.Hour
 
-				// check if data is already sent
-				if !timestamp.After(dbAssetAttribute.LatestTS) {
-					continue
-				}
+func collectAttributeData(ctx context.Context, dbConfig *appdb.Configuration, dbAssetAttribute *appdb.AssetAttribute) error {
+	to := time.Now()
+	windowEnd := dbAssetAttribute.LatestTS.Add(trendWindow).Truncate(time.Second)
+	lagging := windowEnd.Before(to)
+	if lagging {
+		to = windowEnd
+	}
+	apiDataList, err := eliona.GetDataTrends(dbAssetAttribute, dbAssetAttribute.LatestTS, to)
+	if err != nil {
+		return fmt.Errorf("getting data trends: %w", err)
+	}
 
-				// convert data trend to measurement
-				measurement := measurementFromTrend(timestamp, apiData, dbAssetAttribute)
-				if measurement.Value != nil {
-					log.Debug("main", "Sending data for attribute %s: %d", measurement.ReadAt, *measurement.Value)
-					measurements = append(measurements, measurement)
-				}
+	if len(apiDataList) > 0 {
+		log.Debug("main", "Sending for attribute %d %s %s.", dbAssetAttribute.AssetID, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName)
+	}
 
-				// remember latest timestamp
-				if latestTimestamp.Before(timestamp) {
-					latestTimestamp = timestamp
-				}
+	// convert trend data to measurements
+	var measurements []model.Measurement
+	var latestTimestamp = dbAssetAttribute.LatestTS
+	for _, apiData := range apiDataList {
+		if apiData.Timestamp.IsSet() {
+			timestamp := common.Val(apiData.Timestamp.Get())
 
+			// check if data is already sent or belongs to the next window
+			if !timestamp.After(dbAssetAttribute.LatestTS) || (lagging && !timestamp.Before(to)) {
+				continue
+			}
+
+			// convert data trend to measurement
+			measurement := measurementFromTrend(timestamp, apiData, dbAssetAttribute)
+			if measurement.Value != nil {
+				log.Debug("main", "Sending data for attribute %s: %v", measurement.ReadAt, *measurement.Value)
+				measurements = append(measurements, measurement)
 			}
-		}
 
-		// send measurements to Zevvy
-		if len(measurements) > 0 {
-			err := zevvy.SendMeasurements(dbConfig, dbAssetAttribute, measurements)
-			if err != nil {
-				log.Error("Zevvy", "Cannot send measurements to Zevvy: %v", err)
-				return err
+			// remember latest timestamp
+			if latestTimestamp.Before(timestamp) {
+				latestTimestamp = timestamp
 			}
+
 		}
+	}
+
+	// the window is read completely, so the next cycle continues with the next window even without data
+	if lagging && latestTimestamp.Before(to.Add(-time.Nanosecond)) {
+		latestTimestamp = to.Add(-time.Nanosecond)
+	}
+
+	metrics.MeasurementsRead(dbConfig.ID, len(measurements))
+
+	// queue measurements together with the latest timestamp, which includes data without a value
+	err = conf.EnqueueMeasurements(ctx, dbAssetAttribute, measurements, latestTimestamp)
+	if err != nil {
+		return fmt.Errorf("queueing measurements: %w", err)
+	}
+
+	// send all queued measurements to Zevvy
+	return drainOutbox(ctx, dbConfig, dbAssetAttribute)
+}
+
+// maxAttributeBackoff limits the time a failing attribute waits for its next attempt.
+const maxAttributeBackoff = time.Hour
+
+// attributeKey identifies a configured asset attribute.
+type attributeKey struct {
+	configId      int64
+	assetId       int32
+	subtype       string
+	attributeName string
+}
+
+// backoffs holds the delays requested by Zevvy with a Retry-After header for each attribute. The backoff
+// of consecutive failures is computed from the status stored with the attribute, so it survives restarts.
+type backoffs struct {
+	mutex      sync.Mutex
+	retryAfter map[attributeKey]time.Time
+}
+
+var attributeBackoffs = backoffs{retryAfter: make(map[attributeKey]time.Time)}
+
+func keyOf(dbAssetAttribute *appdb.AssetAttribute) attributeKey {
+	return attributeKey{dbAssetAttribute.ConfigID, dbAssetAttribute.AssetID, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName}
+}
 
-		// Store latest timestamp
-		err = conf.UpdateAssetAttributeLatestTimestamp(ctx, dbAssetAttribute, latestTimestamp)
-		if err != nil {
-			log.Error("Conf", "Cannot update latest timestamp: %v", err)
-			return err
+// backoffDelay returns the delay after the consecutive failures of the attribute. The delay doubles with
+// each consecutive failure, starting at the refresh interval of the configuration.
+func backoffDelay(dbConfig *appdb.Configuration, dbAssetAttribute *appdb.AssetAttribute) time.Duration {
+	delay := time.Duration(max(dbConfig.RefreshInterval, 1)) * time.Second
+	for i := int32(1); i < dbAssetAttribute.ConsecutiveFailures && delay < maxAttributeBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxAttributeBackoff)
+}
+
+// isDue returns true if the attribute has no failures or its backoff time has expired.
+func (b *backoffs) isDue(dbConfig *appdb.Configuration, dbAssetAttribute *appdb.AssetAttribute) bool {
+	now := time.Now()
+	if dbAssetAttribute.ConsecutiveFailures > 0 && dbAssetAttribute.LastAttemptTS.Valid &&
+		now.Before(dbAssetAttribute.LastAttemptTS.Time.Add(backoffDelay(dbConfig, dbAssetAttribute))) {
+		return false
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	retryAfter, ok := b.retryAfter[keyOf(dbAssetAttribute)]
+	return !ok || !now.Before(retryAfter)
+}
+
+// failed returns the delay until the next attempt of the attribute, whose failure was already recorded
+// with conf.UpdateAssetAttributeFailure. A delay requested by Zevvy with a Retry-After header is respected.
+func (b *backoffs) failed(dbConfig *appdb.Configuration, dbAssetAttribute *appdb.AssetAttribute, err error) time.Duration {
+	delay := backoffDelay(dbConfig, dbAssetAttribute)
+	var zevvyErr *zevvy.Error
+	if !errors.As(err, &zevvyErr) || zevvyErr.RetryAfter <= delay {
+		return delay
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	// Expired delays are removed, so the ones of deleted attributes don't pile up.
+	now := time.Now()
+	for key, retryAfter := range b.retryAfter {
+		if !now.Before(retryAfter) {
+			delete(b.retryAfter, key)
 		}
 	}
+	b.retryAfter[keyOf(dbAssetAttribute)] = now.Add(zevvyErr.RetryAfter)
+	return zevvyErr.RetryAfter
+}
 
-	return nil
+// succeeded removes the delay requested for the attribute.
+func (b *backoffs) succeeded(dbAssetAttribute *appdb.AssetAttribute) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.retryAfter, keyOf(dbAssetAttribute))
 }
 
 func measurementFromTrend(timestamp time.Time, dataTrend api.Data, dbAssetAttribute *appdb.AssetAttribute) model.Measurement {
-	const outputFormat = "2006-01-02T15:04:05.000Z"
-	measurement := model.Measurement{
-		ReadAt: timestamp.Format(outputFormat),
-	}
+	measurement := model.NewMeasurement(timestamp, nil)
 	if value, ok := dataTrend.Data[dbAssetAttribute.AttributeName]; ok {
-		if intValue, ok := value.(int); ok {
-			measurement.Value = &intValue
-		}
-		if intValue, ok := value.(int32); ok {
-			measurement.Value = common.Ptr(int(intValue))
-		}
-		if intValue, ok := value.(int64); ok {
-			measurement.Value = common.Ptr(int(intValue))
+		var floatValue float64
+		switch v := value.(type) {
+		case int:
+			floatValue = float64(v)
+		case int32:
+			floatValue = float64(v)
+		case int64:
+			floatValue = float64(v)
+		case float32:
+			floatValue = float64(v)
+		case float64:
+			floatValue = v
+		default:
+			return measurement
 		}
-		if intValue, ok := value.(float32); ok {
-			measurement.Value = common.Ptr(int(intValue))
-		}
-		if intValue, ok := value.(float64); ok {
-			measurement.Value = common.Ptr(int(intValue))
+		floatValue = roundValue(floatValue, dbAssetAttribute.Precision)
+		if math.IsNaN(floatValue) || math.IsInf(floatValue, 0) {
+			log.Warn("main", "Skipping non-finite value %v of attribute %s of asset %d at %s", floatValue, dbAssetAttribute.AttributeName, dbAssetAttribute.AssetID, timestamp)
+			return measurement
 		}
+		measurement.Value = common.Ptr(floatValue)
 	}
 	return measurement
 }
 
+// roundValue rounds the value to the given number of decimal places. If no precision
+// is defined, the value is returned with full precision. Precisions above conf.MaxPrecision
+// are capped, so the rounding can't overflow.
+func roundValue(value float64, precision null.Int32) float64 {
+	if !precision.Valid || precision.Int32 < 0 {
+		return value
+	}
+	factor := math.Pow10(int(min(precision.Int32, conf.MaxPrecision)))
+	rounded := math.Round(value*factor) / factor
+	if math.IsInf(rounded, 0) && !math.IsInf(value, 0) {
+		// the value is too large to have decimal places
+		return value
+	}
+	return rounded
+}
+
 func refreshTokens(dbConfig *appdb.Configuration) {
 	log.Info("zevvy", "Get new access token for configuration %d", dbConfig.ID)
-	token, err := zevvy.RefreshTokens(dbConfig)
+	token, err := requestAccessToken(dbConfig)
+	metrics.TokenRefresh(dbConfig.ID, err)
+	if zevvy.IsOAuthError(err, zevvy.ErrorInvalidGrant) && dbConfig.AuthMode != conf.AuthModeClientCredentials {
+		log.Warn("zevvy", "Refresh token of configuration %d was rejected. Starting a new login: %v", dbConfig.ID, err)
+		if err := conf.ResetLogin(dbConfig); err != nil {
+			log.Error("conf", "Cannot reset login in configuration: %v", err)
+			return
+		}
+		startLoginProcess(dbConfig, true)
+		return
+	}
 	if err != nil {
 		log.Error("zevvy", "Cannot get new token: %v", err)
 		return
@@ -222,75 +384,184 @@ func refreshTokens(dbConfig *appdb.Configuration) {
 	}
 }
 
-func startLoginProcess(dbConfig *appdb.Configuration) {
+// requestAccessToken gets a new access token the way defined by the auth mode of the configuration.
+func requestAccessToken(dbConfig *appdb.Configuration) (*model.Token, error) {
+	if dbConfig.AuthMode == conf.AuthModeClientCredentials {
+		return zevvy.GetClientCredentialsTokens(dbConfig)
+	}
+	return zevvy.RefreshTokens(dbConfig)
+}
 
-	// Get verification URI
-	if !conf.IsVerificationUriIsValid(dbConfig) {
+// restartLogin starts a new login, because the refresh token expired. The user is told that the access
+// expired together with the new verification URL.
+func restartLogin(dbConfig *appdb.Configuration) {
+	log.Warn("zevvy", "Refresh token of configuration %d expired at %v. Starting a new login.", dbConfig.ID, dbConfig.RefreshTokenExpire.Time)
+	if err := conf.ResetLogin(dbConfig); err != nil {
+		log.Error("conf", "Cannot reset login in configuration: %v", err)
+		return
+	}
+	startLoginProcess(dbConfig, true)
+}
 
-		// Get verification URL
-		log.Info("zevvy", "Start authentication process for configuration %d", dbConfig.ID)
-		log.Info("zevvy", "Get new verification URL for authentication process for configuration %d", dbConfig.ID)
-		verification, err := zevvy.GetVerification(dbConfig)
-		if err != nil {
-			log.Error("zevvy", "Cannot get verification: %v", err)
-			return
-		}
+func notifyRefreshTokenExpiring(dbConfig *appdb.Configuration) {
+	log.Warn("zevvy", "Refresh token of configuration %d expires at %v", dbConfig.ID, dbConfig.RefreshTokenExpire.Time)
+	expire := dbConfig.RefreshTokenExpire.Time.Format(time.DateTime)
+	err := eliona.NotifyUser(dbConfig.UserID.String, dbConfig.ProjectID.String, api.Translation{
+		De: common.Ptr(fmt.Sprintf("Der Zugriff der Zevvy-App auf die Zevvy-API läuft am %s ab. Danach müssen Sie Ihre Anmeldung erneut verifizieren, damit weiterhin Daten an Zevvy gesendet werden.", expire)),
+		En: common.Ptr(fmt.Sprintf("The Zevvy app's access to the Zevvy API expires on %s. Afterwards you must verify your login again to keep sending data to Zevvy.", expire)),
+	})
+	if err != nil {
+		log.Error("eliona", "Cannot notify user about expiring refresh token: %v", err)
+		return
+	}
+	if err := conf.SetRefreshExpireNotified(dbConfig); err != nil {
+		log.Error("conf", "Cannot update notification state in configuration: %v", err)
+	}
+}
+
+// startLoginProcess runs the device authorization (RFC 8628). If relogin is set, the user is told that the
+// previous login was lost instead of being welcomed to the new configuration.
+func startLoginProcess(dbConfig *appdb.Configuration, relogin bool) {
+	switch {
+	case dbConfig.LoginState.String == conf.LoginStateDenied:
+		return // The access was denied. A new login starts, when the configuration is updated.
+	case !conf.IsInteractiveLogin(dbConfig):
+		denyStaticLogin(dbConfig)
+		return
+	}
 
-		err = conf.UpdateVerification(dbConfig, verification)
-		if err != nil || !conf.IsVerificationUriIsValid(dbConfig) {
-			log.Error("zevvy", "Cannot update verification in configuration: %v", err)
+	switch dbConfig.LoginState.String {
+	case conf.LoginStatePending:
+		if conf.IsVerificationUriIsValid(dbConfig) {
+			pollTokens(dbConfig)
 			return
 		}
+		log.Info("zevvy", "Verification URL for configuration %d expired", dbConfig.ID)
+	}
+	requestVerification(dbConfig, relogin)
+}
 
-		// Notify user
-		log.Info("zevvy", "Notify user about verification URL for authentication process for configuration %d", dbConfig.ID)
-		err = eliona.NotifyUser(dbConfig.UserID.String, dbConfig.ProjectID.String, api.Translation{
-			De: common.Ptr(fmt.Sprintf("Sie haben die Zevvy-App kürzlich eingerichtet. Um der App den Zugriff auf die Zevvy-API zu ermöglichen, müssen Sie Ihre Anmeldung verifizieren: %s", dbConfig.VerificationURI.String)),
-			En: common.Ptr(fmt.Sprintf("You recently set up the Zevvy app. To enable the app's access to the Zevvy API, you must verify your login: %s", dbConfig.VerificationURI.String)),
-		})
-		if err != nil {
-			log.Error("eliona", "Cannot notify user about verification: %v", err)
-			return
+// denyStaticLogin stops the login of a configuration without user interaction, because there is no valid
+// refresh token. The user has to provide a new one by updating the configuration.
+func denyStaticLogin(dbConfig *appdb.Configuration) {
+	log.Error("zevvy", "No valid refresh token for configuration %d. Update the configuration with a new refresh token.", dbConfig.ID)
+	if err := conf.SetLoginDenied(dbConfig); err != nil {
+		log.Error("conf", "Cannot update login state in configuration: %v", err)
+		return
+	}
+	err := eliona.NotifyUser(dbConfig.UserID.String, dbConfig.ProjectID.String, api.Translation{
+		De: common.Ptr("Die Zevvy-App hat kein gültiges Refresh-Token für den Zugriff auf die Zevvy-API. Bitte aktualisieren Sie die Konfiguration mit einem neuen Refresh-Token."),
+		En: common.Ptr("The Zevvy app has no valid refresh token to access the Zevvy API. Please update the configuration with a new refresh token."),
+	})
+	if err != nil {
+		log.Error("eliona", "Cannot notify user about missing refresh token: %v", err)
+	}
+}
+
+func requestVerification(dbConfig *appdb.Configuration, relogin bool) {
+
+	// Get verification URL
+	log.Info("zevvy", "Start authentication process for configuration %d", dbConfig.ID)
+	log.Info("zevvy", "Get new verification URL for authentication process for configuration %d", dbConfig.ID)
+	verification, err := zevvy.GetVerification(dbConfig)
+	if err != nil {
+		log.Error("zevvy", "Cannot get verification: %v", err)
+		return
+	}
+
+	err = conf.UpdateVerification(dbConfig, verification)
+	if err != nil || !conf.IsVerificationUriIsValid(dbConfig) {
+		log.Error("zevvy", "Cannot update verification in configuration: %v", err)
+		return
+	}
+
+	// Notify user
+	log.Info("zevvy", "Notify user about verification URL for authentication process for configuration %d", dbConfig.ID)
+	translation := api.Translation{
+		De: common.Ptr(fmt.Sprintf("Sie haben die Zevvy-App kürzlich eingerichtet. Um der App den Zugriff auf die Zevvy-API zu ermöglichen, müssen Sie Ihre Anmeldung verifizieren: %s", dbConfig.VerificationURI.String)),
+		En: common.Ptr(fmt.Sprintf("You recently set up the Zevvy app. To enable the app's access to the Zevvy API, you must verify your login: %s", dbConfig.VerificationURI.String)),
+	}
+	if relogin {
+		translation = api.Translation{
+			De: common.Ptr(fmt.Sprintf("Der Zugriff der Zevvy-App auf die Zevvy-API ist abgelaufen oder wurde widerrufen. Um weiterhin Daten an Zevvy zu senden, müssen Sie Ihre Anmeldung erneut verifizieren: %s", dbConfig.VerificationURI.String)),
+			En: common.Ptr(fmt.Sprintf("The Zevvy app's access to the Zevvy API has expired or was revoked. To keep sending data to Zevvy, you must verify your login again: %s", dbConfig.VerificationURI.String)),
 		}
 	}
+	err = eliona.NotifyUser(dbConfig.UserID.String, dbConfig.ProjectID.String, translation)
+	if err != nil {
+		log.Error("eliona", "Cannot notify user about verification: %v", err)
+		return
+	}
+}
+
+// pollTokens checks if the user verified the login. The token endpoint is polled not more often than
+// the polling interval, which is increased each time the authorization server asks to slow down.
+func pollTokens(dbConfig *appdb.Configuration) {
+	if !conf.IsPollDue(dbConfig) {
+		return
+	}
 
-	// Check if verification is done
 	token, err := zevvy.GetTokens(dbConfig)
-	if err != nil {
+	switch {
+	case zevvy.IsOAuthError(err, zevvy.ErrorAuthorizationPending):
+		log.Debug("zevvy", "Waiting for verification of configuration %d", dbConfig.ID)
+		err = conf.UpdateNextPoll(dbConfig, false)
+	case zevvy.IsOAuthError(err, zevvy.ErrorSlowDown):
+		log.Info("zevvy", "Slowing down polling for verification of configuration %d", dbConfig.ID)
+		err = conf.UpdateNextPoll(dbConfig, true)
+	case zevvy.IsOAuthError(err, zevvy.ErrorExpiredToken):
+		log.Info("zevvy", "Device code for configuration %d expired", dbConfig.ID)
+		err = conf.ResetLogin(dbConfig)
+	case zevvy.IsOAuthError(err, zevvy.ErrorAccessDenied):
+		log.Warn("zevvy", "User denied the access for configuration %d", dbConfig.ID)
+		err = conf.SetLoginDenied(dbConfig)
+	case err != nil:
 		log.Error("zevvy", "Cannot check verification: %v", err)
+		err = conf.UpdateNextPoll(dbConfig, false)
+	default:
+		updateVerifiedToken(dbConfig, token)
 		return
 	}
+	if err != nil {
+		log.Error("conf", "Cannot update login state in configuration: %v", err)
+	}
+}
 
+func updateVerifiedToken(dbConfig *appdb.Configuration, token *model.Token) {
 	log.Info("zevvy", "Update new access and refresh token %d", dbConfig.ID)
-	err = conf.UpdateToken(dbConfig, token)
+	err := conf.UpdateToken(dbConfig, token)
 	if err != nil || !conf.IsAccessTokenIsValid(dbConfig) {
 		log.Error("zevvy", "Cannot update token in configuration: %v", err)
 		return
-	} else {
-		// Notify user
-		log.Info("zevvy", "Notify user about successful authentication process for configuration %d", dbConfig.ID)
-		err = eliona.NotifyUser(dbConfig.UserID.String, dbConfig.ProjectID.String, api.Translation{
-			De: common.Ptr(fmt.Sprintf("Zevvy App wurde erfolgreich verifiziert.")),
-			En: common.Ptr(fmt.Sprintf("Zevvy app was successful verfied.")),
-		})
-		if err != nil {
-			log.Error("eliona", "Cannot notify user about verification: %v", err)
-			return
-		}
 	}
 
-	return
+	// Notify user
+	log.Info("zevvy", "Notify user about successful authentication process for configuration %d", dbConfig.ID)
+	err = eliona.NotifyUser(dbConfig.UserID.String, dbConfig.ProjectID.String, api.Translation{
+		De: common.Ptr(fmt.Sprintf("Zevvy App wurde erfolgreich verifiziert.")),
+		En: common.Ptr(fmt.Sprintf("Zevvy app was successful verfied.")),
+	})
+	if err != nil {
+		log.Error("eliona", "Cannot notify user about verification: %v", err)
+		return
+	}
 }
 
 // listenApi starts the API server and listen for requests
 func listenApi() {
+	router := apiserver.NewRouter(
+		apiserver.NewConfigurationAPIController(apiservices.NewConfigurationAPIService()),
+		apiserver.NewVersionAPIController(apiservices.NewVersionAPIService()),
+		apiserver.NewAssetAttributeAPIController(apiservices.NewAssetAttributeAPIService()),
+		apiserver.NewMappingRuleAPIController(apiservices.NewMappingRuleAPIService()),
+		apiserver.NewBackfillAPIController(apiservices.NewBackfillAPIService()),
+		apiserver.NewDeadLetterAPIController(apiservices.NewDeadLetterAPIService()),
+	)
+	router.Handle("/metrics", metrics.Handler())
+	router.HandleFunc("/health/live", health.LiveHandler).Methods(http.MethodGet)
+	router.HandleFunc("/health/ready", health.ReadyHandler).Methods(http.MethodGet)
 	err := http.ListenAndServe(":"+common.Getenv("API_SERVER_PORT", "3000"),
 		frontend.NewEnvironmentHandler(
-			utilshttp.NewCORSEnabledHandler(
-				apiserver.NewRouter(
-					apiserver.NewConfigurationAPIController(apiservices.NewConfigurationAPIService()),
-					apiserver.NewVersionAPIController(apiservices.NewVersionAPIService()),
-					apiserver.NewAssetAttributeAPIController(apiservices.NewAssetAttributeAPIService()),
-				))))
+			utilshttp.NewCORSEnabledHandler(router)))
 	log.Fatal("main", "API server: %v", err)
 }
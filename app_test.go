@@ -0,0 +1,94 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"errors"
+	"github.com/volatiletech/null/v8"
+	"math"
+	"testing"
+	"time"
+	"zevvy/appdb"
+	"zevvy/zevvy"
+)
+
+func TestRoundValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     float64
+		precision null.Int32
+		want      float64
+	}{
+		{name: "full precision", value: 1.23456, precision: null.Int32{}, want: 1.23456},
+		{name: "integer", value: 1.5, precision: null.Int32From(0), want: 2},
+		{name: "two decimal places", value: 1.23456, precision: null.Int32From(2), want: 1.23},
+		{name: "negative precision", value: 1.23456, precision: null.Int32From(-1), want: 1.23456},
+		{name: "capped precision", value: 1.5, precision: null.Int32From(400), want: 1.5},
+		{name: "large value", value: 1e300, precision: null.Int32From(15), want: 1e300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := roundValue(tt.value, tt.precision)
+			if math.IsNaN(got) || math.IsInf(got, 0) || got != tt.want {
+				t.Errorf("roundValue(%v, %v) = %v, want %v", tt.value, tt.precision.Int32, got, tt.want)
+			}
+		})
+	}
+}
+
+// The backoff is computed from the failures stored with the attribute, so it continues after a restart.
+func TestAttributeBackoff(t *testing.T) {
+	dbConfig := &appdb.Configuration{RefreshInterval: 60}
+	tests := []struct {
+		name     string
+		failures int32
+		attempt  time.Duration
+		err      error
+		due      bool
+		delay    time.Duration
+		// the delay requested by Zevvy is still pending after the longest backoff
+		pending bool
+	}{
+		{name: "no failures", due: true},
+		{name: "first failure", failures: 1, err: errors.New("timeout"), delay: time.Minute},
+		{name: "third failure", failures: 3, err: errors.New("timeout"), delay: 4 * time.Minute},
+		{name: "third failure waited", failures: 3, attempt: -5 * time.Minute, due: true},
+		{name: "limited", failures: 20, attempt: -30 * time.Minute, err: errors.New("timeout"), delay: maxAttributeBackoff},
+		{name: "retry after", failures: 1, err: &zevvy.Error{StatusCode: 429, Retryable: true, RetryAfter: 10 * time.Minute}, delay: 10 * time.Minute, pending: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := backoffs{retryAfter: make(map[attributeKey]time.Time)}
+			dbAssetAttribute := &appdb.AssetAttribute{ConfigID: 1, AssetID: 1, ConsecutiveFailures: tt.failures}
+			if tt.failures > 0 {
+				dbAssetAttribute.LastAttemptTS = null.TimeFrom(time.Now().Add(tt.attempt))
+			}
+			if got := b.isDue(dbConfig, dbAssetAttribute); got != tt.due {
+				t.Errorf("isDue = %v, want %v", got, tt.due)
+			}
+			if tt.err == nil {
+				return
+			}
+			if got := b.failed(dbConfig, dbAssetAttribute, tt.err); got != tt.delay {
+				t.Errorf("failed = %v, want %v", got, tt.delay)
+			}
+			dbAssetAttribute.LastAttemptTS = null.TimeFrom(time.Now().Add(-maxAttributeBackoff))
+			if got := b.isDue(dbConfig, dbAssetAttribute); got == tt.pending {
+				t.Errorf("isDue after the longest backoff = %v, want %v", got, !tt.pending)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,56 +24,129 @@ import (
 
 // AssetAttribute is an object representing the database table.
 type AssetAttribute struct {
-	ConfigID          int32     `boil:"config_id" json:"config_id" toml:"config_id" yaml:"config_id"`
-	AssetID           int32     `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
-	Subtype           string    `boil:"subtype" json:"subtype" toml:"subtype" yaml:"subtype"`
-	AttributeName     string    `boil:"attribute_name" json:"attribute_name" toml:"attribute_name" yaml:"attribute_name"`
-	DeviceReference   string    `boil:"device_reference" json:"device_reference" toml:"device_reference" yaml:"device_reference"`
-	RegisterReference string    `boil:"register_reference" json:"register_reference" toml:"register_reference" yaml:"register_reference"`
-	LatestTS          time.Time `boil:"latest_ts" json:"latest_ts" toml:"latest_ts" yaml:"latest_ts"`
+	ConfigID                  int64       `boil:"config_id" json:"config_id" toml:"config_id" yaml:"config_id"`
+	AssetID                   int32       `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
+	Subtype                   string      `boil:"subtype" json:"subtype" toml:"subtype" yaml:"subtype"`
+	AttributeName             string      `boil:"attribute_name" json:"attribute_name" toml:"attribute_name" yaml:"attribute_name"`
+	DeviceReference           string      `boil:"device_reference" json:"device_reference" toml:"device_reference" yaml:"device_reference"`
+	RegisterReference         string      `boil:"register_reference" json:"register_reference" toml:"register_reference" yaml:"register_reference"`
+	LatestTS                  time.Time   `boil:"latest_ts" json:"latest_ts" toml:"latest_ts" yaml:"latest_ts"`
+	Precision                 null.Int32  `boil:"precision" json:"precision,omitempty" toml:"precision" yaml:"precision,omitempty"`
+	LastAttemptTS             null.Time   `boil:"last_attempt_ts" json:"last_attempt_ts,omitempty" toml:"last_attempt_ts" yaml:"last_attempt_ts,omitempty"`
+	LastSuccessTS             null.Time   `boil:"last_success_ts" json:"last_success_ts,omitempty" toml:"last_success_ts" yaml:"last_success_ts,omitempty"`
+	LastError                 null.String `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
+	ConsecutiveFailures       int32       `boil:"consecutive_failures" json:"consecutive_failures" toml:"consecutive_failures" yaml:"consecutive_failures"`
+	TotalSent                 int64       `boil:"total_sent" json:"total_sent" toml:"total_sent" yaml:"total_sent"`
+	RuleID                    null.Int64  `boil:"rule_id" json:"rule_id,omitempty" toml:"rule_id" yaml:"rule_id,omitempty"`
+	DeviceReferenceRendered   null.Bool   `boil:"device_reference_rendered" json:"device_reference_rendered,omitempty" toml:"device_reference_rendered" yaml:"device_reference_rendered,omitempty"`
+	RegisterReferenceRendered null.Bool   `boil:"register_reference_rendered" json:"register_reference_rendered,omitempty" toml:"register_reference_rendered" yaml:"register_reference_rendered,omitempty"`
+	RetiredTS                 null.Time   `boil:"retired_ts" json:"retired_ts,omitempty" toml:"retired_ts" yaml:"retired_ts,omitempty"`
 
 	R *assetAttributeR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L assetAttributeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var AssetAttributeColumns = struct {
-	ConfigID          string
-	AssetID           string
-	Subtype           string
-	AttributeName     string
-	DeviceReference   string
-	RegisterReference string
-	LatestTS          string
+	ConfigID                  string
+	AssetID                   string
+	Subtype                   string
+	AttributeName             string
+	DeviceReference           string
+	RegisterReference         string
+	LatestTS                  string
+	Precision                 string
+	LastAttemptTS             string
+	LastSuccessTS             string
+	LastError                 string
+	ConsecutiveFailures       string
+	TotalSent                 string
+	RuleID                    string
+	DeviceReferenceRendered   string
+	RegisterReferenceRendered string
+	RetiredTS                 string
 }{
-	ConfigID:          "config_id",
-	AssetID:           "asset_id",
-	Subtype:           "subtype",
-	AttributeName:     "attribute_name",
-	DeviceReference:   "device_reference",
-	RegisterReference: "register_reference",
-	LatestTS:          "latest_ts",
+	ConfigID:                  "config_id",
+	AssetID:                   "asset_id",
+	Subtype:                   "subtype",
+	AttributeName:             "attribute_name",
+	DeviceReference:           "device_reference",
+	RegisterReference:         "register_reference",
+	LatestTS:                  "latest_ts",
+	Precision:                 "precision",
+	LastAttemptTS:             "last_attempt_ts",
+	LastSuccessTS:             "last_success_ts",
+	LastError:                 "last_error",
+	ConsecutiveFailures:       "consecutive_failures",
+	TotalSent:                 "total_sent",
+	RuleID:                    "rule_id",
+	DeviceReferenceRendered:   "device_reference_rendered",
+	RegisterReferenceRendered: "register_reference_rendered",
+	RetiredTS:                 "retired_ts",
 }
 
 var AssetAttributeTableColumns = struct {
-	ConfigID          string
-	AssetID           string
-	Subtype           string
-	AttributeName     string
-	DeviceReference   string
-	RegisterReference string
-	LatestTS          string
+	ConfigID                  string
+	AssetID                   string
+	Subtype                   string
+	AttributeName             string
+	DeviceReference           string
+	RegisterReference         string
+	LatestTS                  string
+	Precision                 string
+	LastAttemptTS             string
+	LastSuccessTS             string
+	LastError                 string
+	ConsecutiveFailures       string
+	TotalSent                 string
+	RuleID                    string
+	DeviceReferenceRendered   string
+	RegisterReferenceRendered string
+	RetiredTS                 string
 }{
-	ConfigID:          "asset_attribute.config_id",
-	AssetID:           "asset_attribute.asset_id",
-	Subtype:           "asset_attribute.subtype",
-	AttributeName:     "asset_attribute.attribute_name",
-	DeviceReference:   "asset_attribute.device_reference",
-	RegisterReference: "asset_attribute.register_reference",
-	LatestTS:          "asset_attribute.latest_ts",
+	ConfigID:                  "asset_attribute.config_id",
+	AssetID:                   "asset_attribute.asset_id",
+	Subtype:                   "asset_attribute.subtype",
+	AttributeName:             "asset_attribute.attribute_name",
+	DeviceReference:           "asset_attribute.device_reference",
+	RegisterReference:         "asset_attribute.register_reference",
+	LatestTS:                  "asset_attribute.latest_ts",
+	Precision:                 "asset_attribute.precision",
+	LastAttemptTS:             "asset_attribute.last_attempt_ts",
+	LastSuccessTS:             "asset_attribute.last_success_ts",
+	LastError:                 "asset_attribute.last_error",
+	ConsecutiveFailures:       "asset_attribute.consecutive_failures",
+	TotalSent:                 "asset_attribute.total_sent",
+	RuleID:                    "asset_attribute.rule_id",
+	DeviceReferenceRendered:   "asset_attribute.device_reference_rendered",
+	RegisterReferenceRendered: "asset_attribute.register_reference_rendered",
+	RetiredTS:                 "asset_attribute.retired_ts",
 }
 
 // Generated where
 
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
 type whereHelperint32 struct{ field string }
 
 func (w whereHelperint32) EQ(x int32) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
@@ -144,30 +218,231 @@ func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+type whereHelpernull_Int32 struct{ field string }
+
+func (w whereHelpernull_Int32) EQ(x null.Int32) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int32) NEQ(x null.Int32) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int32) LT(x null.Int32) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int32) LTE(x null.Int32) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int32) GT(x null.Int32) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int32) GTE(x null.Int32) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int32) IN(slice []int32) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int32) NIN(slice []int32) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int32) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int32) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" LIKE ?", x)
+}
+func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT LIKE ?", x)
+}
+func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" ILIKE ?", x)
+}
+func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT ILIKE ?", x)
+}
+func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Int64 struct{ field string }
+
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Bool struct{ field string }
+
+func (w whereHelpernull_Bool) EQ(x null.Bool) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Bool) NEQ(x null.Bool) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Bool) LT(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Bool) LTE(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Bool) GT(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Bool) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Bool) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var AssetAttributeWhere = struct {
-	ConfigID          whereHelperint32
-	AssetID           whereHelperint32
-	Subtype           whereHelperstring
-	AttributeName     whereHelperstring
-	DeviceReference   whereHelperstring
-	RegisterReference whereHelperstring
-	LatestTS          whereHelpertime_Time
+	ConfigID                  whereHelperint64
+	AssetID                   whereHelperint32
+	Subtype                   whereHelperstring
+	AttributeName             whereHelperstring
+	DeviceReference           whereHelperstring
+	RegisterReference         whereHelperstring
+	LatestTS                  whereHelpertime_Time
+	Precision                 whereHelpernull_Int32
+	LastAttemptTS             whereHelpernull_Time
+	LastSuccessTS             whereHelpernull_Time
+	LastError                 whereHelpernull_String
+	ConsecutiveFailures       whereHelperint32
+	TotalSent                 whereHelperint64
+	RuleID                    whereHelpernull_Int64
+	DeviceReferenceRendered   whereHelpernull_Bool
+	RegisterReferenceRendered whereHelpernull_Bool
+	RetiredTS                 whereHelpernull_Time
 }{
-	ConfigID:          whereHelperint32{field: "\"zevvy\".\"asset_attribute\".\"config_id\""},
-	AssetID:           whereHelperint32{field: "\"zevvy\".\"asset_attribute\".\"asset_id\""},
-	Subtype:           whereHelperstring{field: "\"zevvy\".\"asset_attribute\".\"subtype\""},
-	AttributeName:     whereHelperstring{field: "\"zevvy\".\"asset_attribute\".\"attribute_name\""},
-	DeviceReference:   whereHelperstring{field: "\"zevvy\".\"asset_attribute\".\"device_reference\""},
-	RegisterReference: whereHelperstring{field: "\"zevvy\".\"asset_attribute\".\"register_reference\""},
-	LatestTS:          whereHelpertime_Time{field: "\"zevvy\".\"asset_attribute\".\"latest_ts\""},
+	ConfigID:                  whereHelperint64{field: "\"zevvy\".\"asset_attribute\".\"config_id\""},
+	AssetID:                   whereHelperint32{field: "\"zevvy\".\"asset_attribute\".\"asset_id\""},
+	Subtype:                   whereHelperstring{field: "\"zevvy\".\"asset_attribute\".\"subtype\""},
+	AttributeName:             whereHelperstring{field: "\"zevvy\".\"asset_attribute\".\"attribute_name\""},
+	DeviceReference:           whereHelperstring{field: "\"zevvy\".\"asset_attribute\".\"device_reference\""},
+	RegisterReference:         whereHelperstring{field: "\"zevvy\".\"asset_attribute\".\"register_reference\""},
+	LatestTS:                  whereHelpertime_Time{field: "\"zevvy\".\"asset_attribute\".\"latest_ts\""},
+	Precision:                 whereHelpernull_Int32{field: "\"zevvy\".\"asset_attribute\".\"precision\""},
+	LastAttemptTS:             whereHelpernull_Time{field: "\"zevvy\".\"asset_attribute\".\"last_attempt_ts\""},
+	LastSuccessTS:             whereHelpernull_Time{field: "\"zevvy\".\"asset_attribute\".\"last_success_ts\""},
+	LastError:                 whereHelpernull_String{field: "\"zevvy\".\"asset_attribute\".\"last_error\""},
+	ConsecutiveFailures:       whereHelperint32{field: "\"zevvy\".\"asset_attribute\".\"consecutive_failures\""},
+	TotalSent:                 whereHelperint64{field: "\"zevvy\".\"asset_attribute\".\"total_sent\""},
+	RuleID:                    whereHelpernull_Int64{field: "\"zevvy\".\"asset_attribute\".\"rule_id\""},
+	DeviceReferenceRendered:   whereHelpernull_Bool{field: "\"zevvy\".\"asset_attribute\".\"device_reference_rendered\""},
+	RegisterReferenceRendered: whereHelpernull_Bool{field: "\"zevvy\".\"asset_attribute\".\"register_reference_rendered\""},
+	RetiredTS:                 whereHelpernull_Time{field: "\"zevvy\".\"asset_attribute\".\"retired_ts\""},
 }
 
 // AssetAttributeRels is where relationship names are stored.
 var AssetAttributeRels = struct {
-}{}
+	Config string
+	Rule   string
+}{
+	Config: "Config",
+	Rule:   "Rule",
+}
 
 // assetAttributeR is where relationships are stored.
 type assetAttributeR struct {
+	Config *Configuration `boil:"Config" json:"Config" toml:"Config" yaml:"Config"`
+	Rule   *MappingRule   `boil:"Rule" json:"Rule" toml:"Rule" yaml:"Rule"`
 }
 
 // NewStruct creates a new relationship struct
@@ -175,13 +450,27 @@ func (*assetAttributeR) NewStruct() *assetAttributeR {
 	return &assetAttributeR{}
 }
 
+func (r *assetAttributeR) GetConfig() *Configuration {
+	if r == nil {
+		return nil
+	}
+	return r.Config
+}
+
+func (r *assetAttributeR) GetRule() *MappingRule {
+	if r == nil {
+		return nil
+	}
+	return r.Rule
+}
+
 // assetAttributeL is where Load methods for each relationship are stored.
 type assetAttributeL struct{}
 
 var (
-	assetAttributeAllColumns            = []string{"config_id", "asset_id", "subtype", "attribute_name", "device_reference", "register_reference", "latest_ts"}
+	assetAttributeAllColumns            = []string{"config_id", "asset_id", "subtype", "attribute_name", "device_reference", "register_reference", "latest_ts", "precision", "last_attempt_ts", "last_success_ts", "last_error", "consecutive_failures", "total_sent", "rule_id", "device_reference_rendered", "register_reference_rendered", "retired_ts"}
 	assetAttributeColumnsWithoutDefault = []string{"config_id", "asset_id", "subtype", "attribute_name", "device_reference", "register_reference"}
-	assetAttributeColumnsWithDefault    = []string{"latest_ts"}
+	assetAttributeColumnsWithDefault    = []string{"latest_ts", "precision", "last_attempt_ts", "last_success_ts", "last_error", "consecutive_failures", "total_sent", "rule_id", "device_reference_rendered", "register_reference_rendered", "retired_ts"}
 	assetAttributePrimaryKeyColumns     = []string{"config_id", "asset_id", "subtype", "attribute_name"}
 	assetAttributeGeneratedColumns      = []string{}
 )
@@ -511,6 +800,423 @@ func (q assetAttributeQuery) Exists(ctx context.Context, exec boil.ContextExecut
 	return count > 0, nil
 }
 
+// Config pointed to by the foreign key.
+func (o *AssetAttribute) Config(mods ...qm.QueryMod) configurationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ConfigID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Configurations(queryMods...)
+}
+
+// Rule pointed to by the foreign key.
+func (o *AssetAttribute) Rule(mods ...qm.QueryMod) mappingRuleQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.RuleID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return MappingRules(queryMods...)
+}
+
+// LoadConfig allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (assetAttributeL) LoadConfig(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAssetAttribute interface{}, mods queries.Applicator) error {
+	var slice []*AssetAttribute
+	var object *AssetAttribute
+
+	if singular {
+		var ok bool
+		object, ok = maybeAssetAttribute.(*AssetAttribute)
+		if !ok {
+			object = new(AssetAttribute)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAssetAttribute)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAssetAttribute))
+			}
+		}
+	} else {
+		s, ok := maybeAssetAttribute.(*[]*AssetAttribute)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAssetAttribute)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAssetAttribute))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &assetAttributeR{}
+		}
+		args[object.ConfigID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &assetAttributeR{}
+			}
+
+			args[obj.ConfigID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`zevvy.configuration`),
+		qm.WhereIn(`zevvy.configuration.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Configuration")
+	}
+
+	var resultSlice []*Configuration
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Configuration")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for configuration")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for configuration")
+	}
+
+	if len(configurationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Config = foreign
+		if foreign.R == nil {
+			foreign.R = &configurationR{}
+		}
+		foreign.R.ConfigAssetAttributes = append(foreign.R.ConfigAssetAttributes, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ConfigID == foreign.ID {
+				local.R.Config = foreign
+				if foreign.R == nil {
+					foreign.R = &configurationR{}
+				}
+				foreign.R.ConfigAssetAttributes = append(foreign.R.ConfigAssetAttributes, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRule allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (assetAttributeL) LoadRule(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAssetAttribute interface{}, mods queries.Applicator) error {
+	var slice []*AssetAttribute
+	var object *AssetAttribute
+
+	if singular {
+		var ok bool
+		object, ok = maybeAssetAttribute.(*AssetAttribute)
+		if !ok {
+			object = new(AssetAttribute)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAssetAttribute)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAssetAttribute))
+			}
+		}
+	} else {
+		s, ok := maybeAssetAttribute.(*[]*AssetAttribute)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAssetAttribute)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAssetAttribute))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &assetAttributeR{}
+		}
+		if !queries.IsNil(object.RuleID) {
+			args[object.RuleID] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &assetAttributeR{}
+			}
+
+			if !queries.IsNil(obj.RuleID) {
+				args[obj.RuleID] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`zevvy.mapping_rule`),
+		qm.WhereIn(`zevvy.mapping_rule.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load MappingRule")
+	}
+
+	var resultSlice []*MappingRule
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice MappingRule")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for mapping_rule")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for mapping_rule")
+	}
+
+	if len(mappingRuleAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Rule = foreign
+		if foreign.R == nil {
+			foreign.R = &mappingRuleR{}
+		}
+		foreign.R.RuleAssetAttributes = append(foreign.R.RuleAssetAttributes, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.RuleID, foreign.ID) {
+				local.R.Rule = foreign
+				if foreign.R == nil {
+					foreign.R = &mappingRuleR{}
+				}
+				foreign.R.RuleAssetAttributes = append(foreign.R.RuleAssetAttributes, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetConfigG of the assetAttribute to the related item.
+// Sets o.R.Config to related.
+// Adds o to related.R.ConfigAssetAttributes.
+// Uses the global database handle.
+func (o *AssetAttribute) SetConfigG(ctx context.Context, insert bool, related *Configuration) error {
+	return o.SetConfig(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetConfig of the assetAttribute to the related item.
+// Sets o.R.Config to related.
+// Adds o to related.R.ConfigAssetAttributes.
+func (o *AssetAttribute) SetConfig(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Configuration) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"zevvy\".\"asset_attribute\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"config_id"}),
+		strmangle.WhereClause("\"", "\"", 2, assetAttributePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ConfigID, o.AssetID, o.Subtype, o.AttributeName}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ConfigID = related.ID
+	if o.R == nil {
+		o.R = &assetAttributeR{
+			Config: related,
+		}
+	} else {
+		o.R.Config = related
+	}
+
+	if related.R == nil {
+		related.R = &configurationR{
+			ConfigAssetAttributes: AssetAttributeSlice{o},
+		}
+	} else {
+		related.R.ConfigAssetAttributes = append(related.R.ConfigAssetAttributes, o)
+	}
+
+	return nil
+}
+
+// SetRuleG of the assetAttribute to the related item.
+// Sets o.R.Rule to related.
+// Adds o to related.R.RuleAssetAttributes.
+// Uses the global database handle.
+func (o *AssetAttribute) SetRuleG(ctx context.Context, insert bool, related *MappingRule) error {
+	return o.SetRule(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetRule of the assetAttribute to the related item.
+// Sets o.R.Rule to related.
+// Adds o to related.R.RuleAssetAttributes.
+func (o *AssetAttribute) SetRule(ctx context.Context, exec boil.ContextExecutor, insert bool, related *MappingRule) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"zevvy\".\"asset_attribute\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"rule_id"}),
+		strmangle.WhereClause("\"", "\"", 2, assetAttributePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ConfigID, o.AssetID, o.Subtype, o.AttributeName}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.RuleID, related.ID)
+	if o.R == nil {
+		o.R = &assetAttributeR{
+			Rule: related,
+		}
+	} else {
+		o.R.Rule = related
+	}
+
+	if related.R == nil {
+		related.R = &mappingRuleR{
+			RuleAssetAttributes: AssetAttributeSlice{o},
+		}
+	} else {
+		related.R.RuleAssetAttributes = append(related.R.RuleAssetAttributes, o)
+	}
+
+	return nil
+}
+
+// RemoveRuleG relationship.
+// Sets o.R.Rule to nil.
+// Removes o from all passed in related items' relationships struct.
+// Uses the global database handle.
+func (o *AssetAttribute) RemoveRuleG(ctx context.Context, related *MappingRule) error {
+	return o.RemoveRule(ctx, boil.GetContextDB(), related)
+}
+
+// RemoveRule relationship.
+// Sets o.R.Rule to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *AssetAttribute) RemoveRule(ctx context.Context, exec boil.ContextExecutor, related *MappingRule) error {
+	var err error
+
+	queries.SetScanner(&o.RuleID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("rule_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Rule = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.RuleAssetAttributes {
+		if queries.Equal(o.RuleID, ri.RuleID) {
+			continue
+		}
+
+		ln := len(related.R.RuleAssetAttributes)
+		if ln > 1 && i < ln-1 {
+			related.R.RuleAssetAttributes[i] = related.R.RuleAssetAttributes[ln-1]
+		}
+		related.R.RuleAssetAttributes = related.R.RuleAssetAttributes[:ln-1]
+		break
+	}
+	return nil
+}
+
 // AssetAttributes retrieves all the records using an executor.
 func AssetAttributes(mods ...qm.QueryMod) assetAttributeQuery {
 	mods = append(mods, qm.From("\"zevvy\".\"asset_attribute\""))
@@ -523,13 +1229,13 @@ func AssetAttributes(mods ...qm.QueryMod) assetAttributeQuery {
 }
 
 // FindAssetAttributeG retrieves a single record by ID.
-func FindAssetAttributeG(ctx context.Context, configID int32, assetID int32, subtype string, attributeName string, selectCols ...string) (*AssetAttribute, error) {
+func FindAssetAttributeG(ctx context.Context, configID int64, assetID int32, subtype string, attributeName string, selectCols ...string) (*AssetAttribute, error) {
 	return FindAssetAttribute(ctx, boil.GetContextDB(), configID, assetID, subtype, attributeName, selectCols...)
 }
 
 // FindAssetAttribute retrieves a single record by ID with an executor.
 // If selectCols is empty Find will return all columns.
-func FindAssetAttribute(ctx context.Context, exec boil.ContextExecutor, configID int32, assetID int32, subtype string, attributeName string, selectCols ...string) (*AssetAttribute, error) {
+func FindAssetAttribute(ctx context.Context, exec boil.ContextExecutor, configID int64, assetID int32, subtype string, attributeName string, selectCols ...string) (*AssetAttribute, error) {
 	assetAttributeObj := &AssetAttribute{}
 
 	sel := "*"
@@ -1094,12 +1800,12 @@ func (o *AssetAttributeSlice) ReloadAll(ctx context.Context, exec boil.ContextEx
 }
 
 // AssetAttributeExistsG checks if the AssetAttribute row exists.
-func AssetAttributeExistsG(ctx context.Context, configID int32, assetID int32, subtype string, attributeName string) (bool, error) {
+func AssetAttributeExistsG(ctx context.Context, configID int64, assetID int32, subtype string, attributeName string) (bool, error) {
 	return AssetAttributeExists(ctx, boil.GetContextDB(), configID, assetID, subtype, attributeName)
 }
 
 // AssetAttributeExists checks if the AssetAttribute row exists.
-func AssetAttributeExists(ctx context.Context, exec boil.ContextExecutor, configID int32, assetID int32, subtype string, attributeName string) (bool, error) {
+func AssetAttributeExists(ctx context.Context, exec boil.ContextExecutor, configID int64, assetID int32, subtype string, attributeName string) (bool, error) {
 	var exists bool
 	sql := "select exists(select 1 from \"zevvy\".\"asset_attribute\" where \"config_id\"=$1 AND \"asset_id\"=$2 AND \"subtype\"=$3 AND \"attribute_name\"=$4 limit 1)"
 
@@ -5,8 +5,16 @@ package appdb
 
 var TableNames = struct {
 	AssetAttribute string
+	Backfill       string
 	Configuration  string
+	DeadLetter     string
+	MappingRule    string
+	Outbox         string
 }{
 	AssetAttribute: "asset_attribute",
+	Backfill:       "backfill",
 	Configuration:  "configuration",
+	DeadLetter:     "dead_letter",
+	MappingRule:    "mapping_rule",
+	Outbox:         "outbox",
 }
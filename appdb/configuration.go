@@ -24,316 +24,236 @@ import (
 
 // Configuration is an object representing the database table.
 type Configuration struct {
-	ID                    int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	AuthRootURL           string      `boil:"auth_root_url" json:"auth_root_url" toml:"auth_root_url" yaml:"auth_root_url"`
-	APIRootURL            string      `boil:"api_root_url" json:"api_root_url" toml:"api_root_url" yaml:"api_root_url"`
-	ClientID              string      `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
-	ClientSecret          string      `boil:"client_secret" json:"client_secret" toml:"client_secret" yaml:"client_secret"`
-	DeviceCode            null.String `boil:"device_code" json:"device_code,omitempty" toml:"device_code" yaml:"device_code,omitempty"`
-	VerificationURI       null.String `boil:"verification_uri" json:"verification_uri,omitempty" toml:"verification_uri" yaml:"verification_uri,omitempty"`
-	VerificationURIExpire null.Time   `boil:"verification_uri_expire" json:"verification_uri_expire,omitempty" toml:"verification_uri_expire" yaml:"verification_uri_expire,omitempty"`
-	VerificationInterval  null.Int32  `boil:"verification_interval" json:"verification_interval,omitempty" toml:"verification_interval" yaml:"verification_interval,omitempty"`
-	AccessToken           null.String `boil:"access_token" json:"access_token,omitempty" toml:"access_token" yaml:"access_token,omitempty"`
-	AccessTokenExpire     null.Time   `boil:"access_token_expire" json:"access_token_expire,omitempty" toml:"access_token_expire" yaml:"access_token_expire,omitempty"`
-	RefreshToken          null.String `boil:"refresh_token" json:"refresh_token,omitempty" toml:"refresh_token" yaml:"refresh_token,omitempty"`
-	RefreshInterval       int32       `boil:"refresh_interval" json:"refresh_interval" toml:"refresh_interval" yaml:"refresh_interval"`
-	RequestTimeout        int32       `boil:"request_timeout" json:"request_timeout" toml:"request_timeout" yaml:"request_timeout"`
-	Active                null.Bool   `boil:"active" json:"active,omitempty" toml:"active" yaml:"active,omitempty"`
-	Enable                null.Bool   `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
-	UserID                null.String `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
-	ProjectID             null.String `boil:"project_id" json:"project_id,omitempty" toml:"project_id" yaml:"project_id,omitempty"`
+	ID                        int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	AuthRootURL               string      `boil:"auth_root_url" json:"auth_root_url" toml:"auth_root_url" yaml:"auth_root_url"`
+	APIRootURL                string      `boil:"api_root_url" json:"api_root_url" toml:"api_root_url" yaml:"api_root_url"`
+	ClientID                  string      `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
+	ClientSecret              string      `boil:"client_secret" json:"client_secret" toml:"client_secret" yaml:"client_secret"`
+	AuthMode                  string      `boil:"auth_mode" json:"auth_mode" toml:"auth_mode" yaml:"auth_mode"`
+	DeviceCode                null.String `boil:"device_code" json:"device_code,omitempty" toml:"device_code" yaml:"device_code,omitempty"`
+	VerificationURI           null.String `boil:"verification_uri" json:"verification_uri,omitempty" toml:"verification_uri" yaml:"verification_uri,omitempty"`
+	VerificationURIExpire     null.Time   `boil:"verification_uri_expire" json:"verification_uri_expire,omitempty" toml:"verification_uri_expire" yaml:"verification_uri_expire,omitempty"`
+	VerificationInterval      null.Int32  `boil:"verification_interval" json:"verification_interval,omitempty" toml:"verification_interval" yaml:"verification_interval,omitempty"`
+	LoginState                null.String `boil:"login_state" json:"login_state,omitempty" toml:"login_state" yaml:"login_state,omitempty"`
+	NextPollTS                null.Time   `boil:"next_poll_ts" json:"next_poll_ts,omitempty" toml:"next_poll_ts" yaml:"next_poll_ts,omitempty"`
+	AccessToken               null.String `boil:"access_token" json:"access_token,omitempty" toml:"access_token" yaml:"access_token,omitempty"`
+	AccessTokenExpire         null.Time   `boil:"access_token_expire" json:"access_token_expire,omitempty" toml:"access_token_expire" yaml:"access_token_expire,omitempty"`
+	RefreshToken              null.String `boil:"refresh_token" json:"refresh_token,omitempty" toml:"refresh_token" yaml:"refresh_token,omitempty"`
+	RefreshTokenExpire        null.Time   `boil:"refresh_token_expire" json:"refresh_token_expire,omitempty" toml:"refresh_token_expire" yaml:"refresh_token_expire,omitempty"`
+	RefreshExpireNotified     bool        `boil:"refresh_expire_notified" json:"refresh_expire_notified" toml:"refresh_expire_notified" yaml:"refresh_expire_notified"`
+	RefreshInterval           int32       `boil:"refresh_interval" json:"refresh_interval" toml:"refresh_interval" yaml:"refresh_interval"`
+	RequestTimeout            int32       `boil:"request_timeout" json:"request_timeout" toml:"request_timeout" yaml:"request_timeout"`
+	BatchSize                 int32       `boil:"batch_size" json:"batch_size" toml:"batch_size" yaml:"batch_size"`
+	Active                    null.Bool   `boil:"active" json:"active,omitempty" toml:"active" yaml:"active,omitempty"`
+	Enable                    null.Bool   `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
+	UserID                    null.String `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	ProjectID                 null.String `boil:"project_id" json:"project_id,omitempty" toml:"project_id" yaml:"project_id,omitempty"`
+	Version                   int32       `boil:"version" json:"version" toml:"version" yaml:"version"`
+	DeviceReferenceTemplate   null.String `boil:"device_reference_template" json:"device_reference_template,omitempty" toml:"device_reference_template" yaml:"device_reference_template,omitempty"`
+	RegisterReferenceTemplate null.String `boil:"register_reference_template" json:"register_reference_template,omitempty" toml:"register_reference_template" yaml:"register_reference_template,omitempty"`
 
 	R *configurationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L configurationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ConfigurationColumns = struct {
-	ID                    string
-	AuthRootURL           string
-	APIRootURL            string
-	ClientID              string
-	ClientSecret          string
-	DeviceCode            string
-	VerificationURI       string
-	VerificationURIExpire string
-	VerificationInterval  string
-	AccessToken           string
-	AccessTokenExpire     string
-	RefreshToken          string
-	RefreshInterval       string
-	RequestTimeout        string
-	Active                string
-	Enable                string
-	UserID                string
-	ProjectID             string
+	ID                        string
+	AuthRootURL               string
+	APIRootURL                string
+	ClientID                  string
+	ClientSecret              string
+	AuthMode                  string
+	DeviceCode                string
+	VerificationURI           string
+	VerificationURIExpire     string
+	VerificationInterval      string
+	LoginState                string
+	NextPollTS                string
+	AccessToken               string
+	AccessTokenExpire         string
+	RefreshToken              string
+	RefreshTokenExpire        string
+	RefreshExpireNotified     string
+	RefreshInterval           string
+	RequestTimeout            string
+	BatchSize                 string
+	Active                    string
+	Enable                    string
+	UserID                    string
+	ProjectID                 string
+	Version                   string
+	DeviceReferenceTemplate   string
+	RegisterReferenceTemplate string
 }{
-	ID:                    "id",
-	AuthRootURL:           "auth_root_url",
-	APIRootURL:            "api_root_url",
-	ClientID:              "client_id",
-	ClientSecret:          "client_secret",
-	DeviceCode:            "device_code",
-	VerificationURI:       "verification_uri",
-	VerificationURIExpire: "verification_uri_expire",
-	VerificationInterval:  "verification_interval",
-	AccessToken:           "access_token",
-	AccessTokenExpire:     "access_token_expire",
-	RefreshToken:          "refresh_token",
-	RefreshInterval:       "refresh_interval",
-	RequestTimeout:        "request_timeout",
-	Active:                "active",
-	Enable:                "enable",
-	UserID:                "user_id",
-	ProjectID:             "project_id",
+	ID:                        "id",
+	AuthRootURL:               "auth_root_url",
+	APIRootURL:                "api_root_url",
+	ClientID:                  "client_id",
+	ClientSecret:              "client_secret",
+	AuthMode:                  "auth_mode",
+	DeviceCode:                "device_code",
+	VerificationURI:           "verification_uri",
+	VerificationURIExpire:     "verification_uri_expire",
+	VerificationInterval:      "verification_interval",
+	LoginState:                "login_state",
+	NextPollTS:                "next_poll_ts",
+	AccessToken:               "access_token",
+	AccessTokenExpire:         "access_token_expire",
+	RefreshToken:              "refresh_token",
+	RefreshTokenExpire:        "refresh_token_expire",
+	RefreshExpireNotified:     "refresh_expire_notified",
+	RefreshInterval:           "refresh_interval",
+	RequestTimeout:            "request_timeout",
+	BatchSize:                 "batch_size",
+	Active:                    "active",
+	Enable:                    "enable",
+	UserID:                    "user_id",
+	ProjectID:                 "project_id",
+	Version:                   "version",
+	DeviceReferenceTemplate:   "device_reference_template",
+	RegisterReferenceTemplate: "register_reference_template",
 }
 
 var ConfigurationTableColumns = struct {
-	ID                    string
-	AuthRootURL           string
-	APIRootURL            string
-	ClientID              string
-	ClientSecret          string
-	DeviceCode            string
-	VerificationURI       string
-	VerificationURIExpire string
-	VerificationInterval  string
-	AccessToken           string
-	AccessTokenExpire     string
-	RefreshToken          string
-	RefreshInterval       string
-	RequestTimeout        string
-	Active                string
-	Enable                string
-	UserID                string
-	ProjectID             string
+	ID                        string
+	AuthRootURL               string
+	APIRootURL                string
+	ClientID                  string
+	ClientSecret              string
+	AuthMode                  string
+	DeviceCode                string
+	VerificationURI           string
+	VerificationURIExpire     string
+	VerificationInterval      string
+	LoginState                string
+	NextPollTS                string
+	AccessToken               string
+	AccessTokenExpire         string
+	RefreshToken              string
+	RefreshTokenExpire        string
+	RefreshExpireNotified     string
+	RefreshInterval           string
+	RequestTimeout            string
+	BatchSize                 string
+	Active                    string
+	Enable                    string
+	UserID                    string
+	ProjectID                 string
+	Version                   string
+	DeviceReferenceTemplate   string
+	RegisterReferenceTemplate string
 }{
-	ID:                    "configuration.id",
-	AuthRootURL:           "configuration.auth_root_url",
-	APIRootURL:            "configuration.api_root_url",
-	ClientID:              "configuration.client_id",
-	ClientSecret:          "configuration.client_secret",
-	DeviceCode:            "configuration.device_code",
-	VerificationURI:       "configuration.verification_uri",
-	VerificationURIExpire: "configuration.verification_uri_expire",
-	VerificationInterval:  "configuration.verification_interval",
-	AccessToken:           "configuration.access_token",
-	AccessTokenExpire:     "configuration.access_token_expire",
-	RefreshToken:          "configuration.refresh_token",
-	RefreshInterval:       "configuration.refresh_interval",
-	RequestTimeout:        "configuration.request_timeout",
-	Active:                "configuration.active",
-	Enable:                "configuration.enable",
-	UserID:                "configuration.user_id",
-	ProjectID:             "configuration.project_id",
+	ID:                        "configuration.id",
+	AuthRootURL:               "configuration.auth_root_url",
+	APIRootURL:                "configuration.api_root_url",
+	ClientID:                  "configuration.client_id",
+	ClientSecret:              "configuration.client_secret",
+	AuthMode:                  "configuration.auth_mode",
+	DeviceCode:                "configuration.device_code",
+	VerificationURI:           "configuration.verification_uri",
+	VerificationURIExpire:     "configuration.verification_uri_expire",
+	VerificationInterval:      "configuration.verification_interval",
+	LoginState:                "configuration.login_state",
+	NextPollTS:                "configuration.next_poll_ts",
+	AccessToken:               "configuration.access_token",
+	AccessTokenExpire:         "configuration.access_token_expire",
+	RefreshToken:              "configuration.refresh_token",
+	RefreshTokenExpire:        "configuration.refresh_token_expire",
+	RefreshExpireNotified:     "configuration.refresh_expire_notified",
+	RefreshInterval:           "configuration.refresh_interval",
+	RequestTimeout:            "configuration.request_timeout",
+	BatchSize:                 "configuration.batch_size",
+	Active:                    "configuration.active",
+	Enable:                    "configuration.enable",
+	UserID:                    "configuration.user_id",
+	ProjectID:                 "configuration.project_id",
+	Version:                   "configuration.version",
+	DeviceReferenceTemplate:   "configuration.device_reference_template",
+	RegisterReferenceTemplate: "configuration.register_reference_template",
 }
 
 // Generated where
 
-type whereHelperint64 struct{ field string }
+type whereHelperbool struct{ field string }
 
-func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" LIKE ?", x)
-}
-func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT LIKE ?", x)
-}
-func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" ILIKE ?", x)
-}
-func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT ILIKE ?", x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpernull_Time struct{ field string }
-
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpernull_Int32 struct{ field string }
-
-func (w whereHelpernull_Int32) EQ(x null.Int32) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Int32) NEQ(x null.Int32) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Int32) LT(x null.Int32) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Int32) LTE(x null.Int32) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Int32) GT(x null.Int32) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Int32) GTE(x null.Int32) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_Int32) IN(slice []int32) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_Int32) NIN(slice []int32) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_Int32) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Int32) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelpernull_Bool struct{ field string }
-
-func (w whereHelpernull_Bool) EQ(x null.Bool) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Bool) NEQ(x null.Bool) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Bool) LT(x null.Bool) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Bool) LTE(x null.Bool) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Bool) GT(x null.Bool) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-func (w whereHelpernull_Bool) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Bool) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
 var ConfigurationWhere = struct {
-	ID                    whereHelperint64
-	AuthRootURL           whereHelperstring
-	APIRootURL            whereHelperstring
-	ClientID              whereHelperstring
-	ClientSecret          whereHelperstring
-	DeviceCode            whereHelpernull_String
-	VerificationURI       whereHelpernull_String
-	VerificationURIExpire whereHelpernull_Time
-	VerificationInterval  whereHelpernull_Int32
-	AccessToken           whereHelpernull_String
-	AccessTokenExpire     whereHelpernull_Time
-	RefreshToken          whereHelpernull_String
-	RefreshInterval       whereHelperint32
-	RequestTimeout        whereHelperint32
-	Active                whereHelpernull_Bool
-	Enable                whereHelpernull_Bool
-	UserID                whereHelpernull_String
-	ProjectID             whereHelpernull_String
+	ID                        whereHelperint64
+	AuthRootURL               whereHelperstring
+	APIRootURL                whereHelperstring
+	ClientID                  whereHelperstring
+	ClientSecret              whereHelperstring
+	AuthMode                  whereHelperstring
+	DeviceCode                whereHelpernull_String
+	VerificationURI           whereHelpernull_String
+	VerificationURIExpire     whereHelpernull_Time
+	VerificationInterval      whereHelpernull_Int32
+	LoginState                whereHelpernull_String
+	NextPollTS                whereHelpernull_Time
+	AccessToken               whereHelpernull_String
+	AccessTokenExpire         whereHelpernull_Time
+	RefreshToken              whereHelpernull_String
+	RefreshTokenExpire        whereHelpernull_Time
+	RefreshExpireNotified     whereHelperbool
+	RefreshInterval           whereHelperint32
+	RequestTimeout            whereHelperint32
+	BatchSize                 whereHelperint32
+	Active                    whereHelpernull_Bool
+	Enable                    whereHelpernull_Bool
+	UserID                    whereHelpernull_String
+	ProjectID                 whereHelpernull_String
+	Version                   whereHelperint32
+	DeviceReferenceTemplate   whereHelpernull_String
+	RegisterReferenceTemplate whereHelpernull_String
 }{
-	ID:                    whereHelperint64{field: "\"zevvy\".\"configuration\".\"id\""},
-	AuthRootURL:           whereHelperstring{field: "\"zevvy\".\"configuration\".\"auth_root_url\""},
-	APIRootURL:            whereHelperstring{field: "\"zevvy\".\"configuration\".\"api_root_url\""},
-	ClientID:              whereHelperstring{field: "\"zevvy\".\"configuration\".\"client_id\""},
-	ClientSecret:          whereHelperstring{field: "\"zevvy\".\"configuration\".\"client_secret\""},
-	DeviceCode:            whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"device_code\""},
-	VerificationURI:       whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"verification_uri\""},
-	VerificationURIExpire: whereHelpernull_Time{field: "\"zevvy\".\"configuration\".\"verification_uri_expire\""},
-	VerificationInterval:  whereHelpernull_Int32{field: "\"zevvy\".\"configuration\".\"verification_interval\""},
-	AccessToken:           whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"access_token\""},
-	AccessTokenExpire:     whereHelpernull_Time{field: "\"zevvy\".\"configuration\".\"access_token_expire\""},
-	RefreshToken:          whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"refresh_token\""},
-	RefreshInterval:       whereHelperint32{field: "\"zevvy\".\"configuration\".\"refresh_interval\""},
-	RequestTimeout:        whereHelperint32{field: "\"zevvy\".\"configuration\".\"request_timeout\""},
-	Active:                whereHelpernull_Bool{field: "\"zevvy\".\"configuration\".\"active\""},
-	Enable:                whereHelpernull_Bool{field: "\"zevvy\".\"configuration\".\"enable\""},
-	UserID:                whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"user_id\""},
-	ProjectID:             whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"project_id\""},
+	ID:                        whereHelperint64{field: "\"zevvy\".\"configuration\".\"id\""},
+	AuthRootURL:               whereHelperstring{field: "\"zevvy\".\"configuration\".\"auth_root_url\""},
+	APIRootURL:                whereHelperstring{field: "\"zevvy\".\"configuration\".\"api_root_url\""},
+	ClientID:                  whereHelperstring{field: "\"zevvy\".\"configuration\".\"client_id\""},
+	ClientSecret:              whereHelperstring{field: "\"zevvy\".\"configuration\".\"client_secret\""},
+	AuthMode:                  whereHelperstring{field: "\"zevvy\".\"configuration\".\"auth_mode\""},
+	DeviceCode:                whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"device_code\""},
+	VerificationURI:           whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"verification_uri\""},
+	VerificationURIExpire:     whereHelpernull_Time{field: "\"zevvy\".\"configuration\".\"verification_uri_expire\""},
+	VerificationInterval:      whereHelpernull_Int32{field: "\"zevvy\".\"configuration\".\"verification_interval\""},
+	LoginState:                whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"login_state\""},
+	NextPollTS:                whereHelpernull_Time{field: "\"zevvy\".\"configuration\".\"next_poll_ts\""},
+	AccessToken:               whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"access_token\""},
+	AccessTokenExpire:         whereHelpernull_Time{field: "\"zevvy\".\"configuration\".\"access_token_expire\""},
+	RefreshToken:              whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"refresh_token\""},
+	RefreshTokenExpire:        whereHelpernull_Time{field: "\"zevvy\".\"configuration\".\"refresh_token_expire\""},
+	RefreshExpireNotified:     whereHelperbool{field: "\"zevvy\".\"configuration\".\"refresh_expire_notified\""},
+	RefreshInterval:           whereHelperint32{field: "\"zevvy\".\"configuration\".\"refresh_interval\""},
+	RequestTimeout:            whereHelperint32{field: "\"zevvy\".\"configuration\".\"request_timeout\""},
+	BatchSize:                 whereHelperint32{field: "\"zevvy\".\"configuration\".\"batch_size\""},
+	Active:                    whereHelpernull_Bool{field: "\"zevvy\".\"configuration\".\"active\""},
+	Enable:                    whereHelpernull_Bool{field: "\"zevvy\".\"configuration\".\"enable\""},
+	UserID:                    whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"user_id\""},
+	ProjectID:                 whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"project_id\""},
+	Version:                   whereHelperint32{field: "\"zevvy\".\"configuration\".\"version\""},
+	DeviceReferenceTemplate:   whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"device_reference_template\""},
+	RegisterReferenceTemplate: whereHelpernull_String{field: "\"zevvy\".\"configuration\".\"register_reference_template\""},
 }
 
 // ConfigurationRels is where relationship names are stored.
 var ConfigurationRels = struct {
-}{}
+	ConfigAssetAttributes string
+	ConfigMappingRules    string
+}{
+	ConfigAssetAttributes: "ConfigAssetAttributes",
+	ConfigMappingRules:    "ConfigMappingRules",
+}
 
 // configurationR is where relationships are stored.
 type configurationR struct {
+	ConfigAssetAttributes AssetAttributeSlice `boil:"ConfigAssetAttributes" json:"ConfigAssetAttributes" toml:"ConfigAssetAttributes" yaml:"ConfigAssetAttributes"`
+	ConfigMappingRules    MappingRuleSlice    `boil:"ConfigMappingRules" json:"ConfigMappingRules" toml:"ConfigMappingRules" yaml:"ConfigMappingRules"`
 }
 
 // NewStruct creates a new relationship struct
@@ -341,13 +261,27 @@ func (*configurationR) NewStruct() *configurationR {
 	return &configurationR{}
 }
 
+func (r *configurationR) GetConfigAssetAttributes() AssetAttributeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.ConfigAssetAttributes
+}
+
+func (r *configurationR) GetConfigMappingRules() MappingRuleSlice {
+	if r == nil {
+		return nil
+	}
+	return r.ConfigMappingRules
+}
+
 // configurationL is where Load methods for each relationship are stored.
 type configurationL struct{}
 
 var (
-	configurationAllColumns            = []string{"id", "auth_root_url", "api_root_url", "client_id", "client_secret", "device_code", "verification_uri", "verification_uri_expire", "verification_interval", "access_token", "access_token_expire", "refresh_token", "refresh_interval", "request_timeout", "active", "enable", "user_id", "project_id"}
+	configurationAllColumns            = []string{"id", "auth_root_url", "api_root_url", "client_id", "client_secret", "auth_mode", "device_code", "verification_uri", "verification_uri_expire", "verification_interval", "login_state", "next_poll_ts", "access_token", "access_token_expire", "refresh_token", "refresh_token_expire", "refresh_expire_notified", "refresh_interval", "request_timeout", "batch_size", "active", "enable", "user_id", "project_id", "version", "device_reference_template", "register_reference_template"}
 	configurationColumnsWithoutDefault = []string{"auth_root_url", "api_root_url", "client_id", "client_secret"}
-	configurationColumnsWithDefault    = []string{"id", "device_code", "verification_uri", "verification_uri_expire", "verification_interval", "access_token", "access_token_expire", "refresh_token", "refresh_interval", "request_timeout", "active", "enable", "user_id", "project_id"}
+	configurationColumnsWithDefault    = []string{"id", "auth_mode", "device_code", "verification_uri", "verification_uri_expire", "verification_interval", "login_state", "next_poll_ts", "access_token", "access_token_expire", "refresh_token", "refresh_token_expire", "refresh_expire_notified", "refresh_interval", "request_timeout", "batch_size", "active", "enable", "user_id", "project_id", "version", "device_reference_template", "register_reference_template"}
 	configurationPrimaryKeyColumns     = []string{"id"}
 	configurationGeneratedColumns      = []string{}
 )
@@ -677,6 +611,384 @@ func (q configurationQuery) Exists(ctx context.Context, exec boil.ContextExecuto
 	return count > 0, nil
 }
 
+// ConfigAssetAttributes retrieves all the asset_attribute's AssetAttributes with an executor via config_id column.
+func (o *Configuration) ConfigAssetAttributes(mods ...qm.QueryMod) assetAttributeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"zevvy\".\"asset_attribute\".\"config_id\"=?", o.ID),
+	)
+
+	return AssetAttributes(queryMods...)
+}
+
+// ConfigMappingRules retrieves all the mapping_rule's MappingRules with an executor via config_id column.
+func (o *Configuration) ConfigMappingRules(mods ...qm.QueryMod) mappingRuleQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"zevvy\".\"mapping_rule\".\"config_id\"=?", o.ID),
+	)
+
+	return MappingRules(queryMods...)
+}
+
+// LoadConfigAssetAttributes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (configurationL) LoadConfigAssetAttributes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
+	var slice []*Configuration
+	var object *Configuration
+
+	if singular {
+		var ok bool
+		object, ok = maybeConfiguration.(*Configuration)
+		if !ok {
+			object = new(Configuration)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeConfiguration))
+			}
+		}
+	} else {
+		s, ok := maybeConfiguration.(*[]*Configuration)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeConfiguration))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &configurationR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &configurationR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`zevvy.asset_attribute`),
+		qm.WhereIn(`zevvy.asset_attribute.config_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load asset_attribute")
+	}
+
+	var resultSlice []*AssetAttribute
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice asset_attribute")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on asset_attribute")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for asset_attribute")
+	}
+
+	if len(assetAttributeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ConfigAssetAttributes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &assetAttributeR{}
+			}
+			foreign.R.Config = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ConfigID {
+				local.R.ConfigAssetAttributes = append(local.R.ConfigAssetAttributes, foreign)
+				if foreign.R == nil {
+					foreign.R = &assetAttributeR{}
+				}
+				foreign.R.Config = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadConfigMappingRules allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (configurationL) LoadConfigMappingRules(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
+	var slice []*Configuration
+	var object *Configuration
+
+	if singular {
+		var ok bool
+		object, ok = maybeConfiguration.(*Configuration)
+		if !ok {
+			object = new(Configuration)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeConfiguration))
+			}
+		}
+	} else {
+		s, ok := maybeConfiguration.(*[]*Configuration)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeConfiguration))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &configurationR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &configurationR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`zevvy.mapping_rule`),
+		qm.WhereIn(`zevvy.mapping_rule.config_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load mapping_rule")
+	}
+
+	var resultSlice []*MappingRule
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice mapping_rule")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on mapping_rule")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for mapping_rule")
+	}
+
+	if len(mappingRuleAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ConfigMappingRules = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &mappingRuleR{}
+			}
+			foreign.R.Config = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ConfigID {
+				local.R.ConfigMappingRules = append(local.R.ConfigMappingRules, foreign)
+				if foreign.R == nil {
+					foreign.R = &mappingRuleR{}
+				}
+				foreign.R.Config = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddConfigAssetAttributesG adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.ConfigAssetAttributes.
+// Sets related.R.Config appropriately.
+// Uses the global database handle.
+func (o *Configuration) AddConfigAssetAttributesG(ctx context.Context, insert bool, related ...*AssetAttribute) error {
+	return o.AddConfigAssetAttributes(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddConfigAssetAttributes adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.ConfigAssetAttributes.
+// Sets related.R.Config appropriately.
+func (o *Configuration) AddConfigAssetAttributes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*AssetAttribute) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ConfigID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"zevvy\".\"asset_attribute\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"config_id"}),
+				strmangle.WhereClause("\"", "\"", 2, assetAttributePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ConfigID, rel.AssetID, rel.Subtype, rel.AttributeName}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ConfigID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &configurationR{
+			ConfigAssetAttributes: related,
+		}
+	} else {
+		o.R.ConfigAssetAttributes = append(o.R.ConfigAssetAttributes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &assetAttributeR{
+				Config: o,
+			}
+		} else {
+			rel.R.Config = o
+		}
+	}
+	return nil
+}
+
+// AddConfigMappingRulesG adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.ConfigMappingRules.
+// Sets related.R.Config appropriately.
+// Uses the global database handle.
+func (o *Configuration) AddConfigMappingRulesG(ctx context.Context, insert bool, related ...*MappingRule) error {
+	return o.AddConfigMappingRules(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddConfigMappingRules adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.ConfigMappingRules.
+// Sets related.R.Config appropriately.
+func (o *Configuration) AddConfigMappingRules(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*MappingRule) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ConfigID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"zevvy\".\"mapping_rule\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"config_id"}),
+				strmangle.WhereClause("\"", "\"", 2, mappingRulePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ConfigID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &configurationR{
+			ConfigMappingRules: related,
+		}
+	} else {
+		o.R.ConfigMappingRules = append(o.R.ConfigMappingRules, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &mappingRuleR{
+				Config: o,
+			}
+		} else {
+			rel.R.Config = o
+		}
+	}
+	return nil
+}
+
 // Configurations retrieves all the records using an executor.
 func Configurations(mods ...qm.QueryMod) configurationQuery {
 	mods = append(mods, qm.From("\"zevvy\".\"configuration\""))
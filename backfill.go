@@ -0,0 +1,132 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"time"
+	"zevvy/appdb"
+	"zevvy/conf"
+	"zevvy/eliona"
+	"zevvy/model"
+)
+
+// backfillWindow is the time range of historical data read from Eliona and sent to Zevvy at once.
+// The progress of a backfill job is stored after each window, so an interrupted job resumes there.
+const backfillWindow = 24 * time.Hour
+
+func backfill() {
+	dbBackfills, err := conf.GetDbOpenBackfills(context.Background())
+	if err != nil {
+		log.Error("conf", "Couldn't read backfills from DB: %v", err)
+		return
+	}
+
+	for _, dbBackfill := range dbBackfills {
+		common.RunOnceWithParam(func(dbBackfill appdb.Backfill) {
+			runBackfill(&dbBackfill)
+		}, *dbBackfill, fmt.Sprintf("backfill %d", dbBackfill.ID))
+	}
+}
+
+// runBackfill sends the historical data of the backfill job window by window until the end is reached.
+func runBackfill(dbBackfill *appdb.Backfill) {
+	ctx := context.Background()
+
+	dbAssetAttribute, err := conf.GetDbAssetAttributeForBackfill(ctx, dbBackfill)
+	if errors.Is(err, conf.ErrNotFound) {
+		log.Warn("main", "Asset attribute for backfill %d no longer configured.", dbBackfill.ID)
+		_ = conf.UpdateBackfillError(ctx, dbBackfill, fmt.Errorf("asset attribute no longer configured"), true)
+		return
+	}
+	if err != nil {
+		log.Error("conf", "Cannot get asset attribute for backfill %d: %v", dbBackfill.ID, err)
+		return
+	}
+
+	log.Info("main", "Backfill %d started at %v.", dbBackfill.ID, dbBackfill.ProgressTS)
+	for dbBackfill.ProgressTS.Before(dbBackfill.EndTS) {
+
+		// Only queue data if the configuration is enabled.
+		dbConfig, err := conf.GetDbConfig(ctx, dbBackfill.ConfigID)
+		if err != nil {
+			log.Error("conf", "Cannot get configuration for backfill %d: %v", dbBackfill.ID, err)
+			return
+		}
+		if !conf.IsDbConfigEnabled(dbConfig) {
+			log.Debug("main", "Backfill %d waits for configuration %d.", dbBackfill.ID, dbConfig.ID)
+			time.Sleep(time.Second * time.Duration(dbConfig.RefreshInterval))
+			return
+		}
+
+		to := dbBackfill.ProgressTS.Add(backfillWindow)
+		if to.After(dbBackfill.EndTS) {
+			to = dbBackfill.EndTS
+		}
+
+		if err := backfillWindowData(ctx, dbAssetAttribute, dbBackfill.ProgressTS, to); err != nil {
+			_ = conf.UpdateBackfillError(ctx, dbBackfill, err, false)
+			time.Sleep(time.Second * time.Duration(dbConfig.RefreshInterval))
+			return
+		}
+
+		if err := conf.UpdateBackfillProgress(ctx, dbBackfill, to); err != nil {
+			log.Error("conf", "Cannot update progress of backfill %d: %v", dbBackfill.ID, err)
+			return
+		}
+	}
+	log.Info("main", "Backfill %d finished.", dbBackfill.ID)
+}
+
+// backfillWindowData queues the data stored in Eliona between from (inclusive) and to (exclusive) in the
+// outbox. The queued measurements are sent to Zevvy together with the current data of the attribute.
+func backfillWindowData(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute, from time.Time, to time.Time) error {
+	apiDataList, err := eliona.GetDataTrends(dbAssetAttribute, from, to)
+	if err != nil {
+		log.Error("Eliona", "Cannot get data trends: %v", err)
+		return err
+	}
+
+	var measurements []model.Measurement
+	for _, apiData := range apiDataList {
+		if apiData.Timestamp.IsSet() {
+			timestamp := common.Val(apiData.Timestamp.Get())
+			if timestamp.Before(from) || !timestamp.Before(to) {
+				continue
+			}
+			measurement := measurementFromTrend(timestamp, apiData, dbAssetAttribute)
+			if measurement.Value != nil {
+				measurements = append(measurements, measurement)
+			}
+		}
+	}
+
+	if len(measurements) > 0 {
+		log.Debug("main", "Backfilling %d measurements for attribute %d %s %s.", len(measurements), dbAssetAttribute.AssetID, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName)
+	}
+
+	// The historical data is older than the latest timestamp, so the latest timestamp is kept.
+	err = conf.EnqueueMeasurements(ctx, dbAssetAttribute, measurements, time.Time{})
+	if err != nil {
+		log.Error("conf", "Cannot queue measurements: %v", err)
+		return err
+	}
+	return nil
+}
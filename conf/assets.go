@@ -17,8 +17,11 @@ package conf
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"strings"
 	"time"
@@ -38,16 +41,32 @@ func UpsertAssetAttribute(ctx context.Context, apiAssetAttribute *apiserver.Asse
 	if apiAsset == nil {
 		return apiAssetAttribute, ErrNotFound
 	}
-	if len(dbAssetAttribute.DeviceReference) == 0 {
-		dbAssetAttribute.DeviceReference = strings.Trim(apiAsset.GlobalAssetIdentifier, " ")
+	if err := validateMapping(ctx, dbAssetAttribute, apiAsset); err != nil {
+		return apiAssetAttribute, err
+	}
+	renderer, err := newReferenceRenderer(ctx, dbAssetAttribute.ConfigID)
+	if err != nil {
+		return apiAssetAttribute, err
 	}
-	dbAssetAttribute.DeviceReference = strings.Replace(dbAssetAttribute.DeviceReference, "/", "_", -1)
-	if len(dbAssetAttribute.RegisterReference) == 0 {
-		dbAssetAttribute.RegisterReference = strings.Trim(apiAssetAttribute.AttributeName, " ")
+	if err := renderer.setReferences(dbAssetAttribute, apiAsset); err != nil {
+		return apiAssetAttribute, err
 	}
-	dbAssetAttribute.RegisterReference = strings.Replace(dbAssetAttribute.RegisterReference, "/", "_", -1)
 
-	err = dbAssetAttribute.UpsertG(ctx, true,
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return apiAssetAttribute, fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if err := lockReferences(ctx, tx, dbAssetAttribute.ConfigID); err != nil {
+		return apiAssetAttribute, err
+	}
+	if err := checkReferenceCollision(ctx, tx, dbAssetAttribute); err != nil {
+		return apiAssetAttribute, err
+	}
+
+	err = dbAssetAttribute.Upsert(ctx, tx, true,
 		[]string{
 			appdb.AssetAttributeColumns.ConfigID,
 			appdb.AssetAttributeColumns.AssetID,
@@ -57,7 +76,11 @@ func UpsertAssetAttribute(ctx context.Context, apiAssetAttribute *apiserver.Asse
 		boil.Whitelist(
 			appdb.AssetAttributeColumns.DeviceReference,
 			appdb.AssetAttributeColumns.RegisterReference,
+			appdb.AssetAttributeColumns.DeviceReferenceRendered,
+			appdb.AssetAttributeColumns.RegisterReferenceRendered,
 			appdb.AssetAttributeColumns.LatestTS,
+			appdb.AssetAttributeColumns.Precision,
+			appdb.AssetAttributeColumns.RetiredTS,
 		),
 		boil.Whitelist(
 			appdb.AssetAttributeColumns.ConfigID,
@@ -66,23 +89,442 @@ func UpsertAssetAttribute(ctx context.Context, apiAssetAttribute *apiserver.Asse
 			appdb.AssetAttributeColumns.AttributeName,
 			appdb.AssetAttributeColumns.DeviceReference,
 			appdb.AssetAttributeColumns.RegisterReference,
+			appdb.AssetAttributeColumns.DeviceReferenceRendered,
+			appdb.AssetAttributeColumns.RegisterReferenceRendered,
 			appdb.AssetAttributeColumns.LatestTS,
+			appdb.AssetAttributeColumns.Precision,
 		),
 	)
 	if err != nil {
 		return apiAssetAttribute, err
 	}
+	if err := tx.Commit(); err != nil {
+		return apiAssetAttribute, fmt.Errorf("committing transaction: %v", err)
+	}
 	return apiAssetAttributeFromDbAssetAttribute(dbAssetAttribute), nil
 }
 
+// validateMapping checks that the configuration of the asset attribute exists and that the attribute is
+// defined in the attribute schema of the asset type.
+func validateMapping(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute, apiAsset *api.Asset) error {
+	validationErr := &ValidationError{}
+	exists, err := appdb.ConfigurationExistsG(ctx, dbAssetAttribute.ConfigID)
+	if err != nil {
+		return fmt.Errorf("checking configuration: %v", err)
+	}
+	if !exists {
+		validationErr.Add("configId", "configuration %d doesn't exist", dbAssetAttribute.ConfigID)
+	}
+	assetType, err := eliona.GetAssetType(apiAsset.AssetType)
+	if err != nil {
+		return err
+	}
+	validateAttributeSchema(validationErr, "assetId", apiAsset.AssetType, assetType, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName)
+	validatePrecision(validationErr, dbAssetAttribute.Precision)
+	return validationErr.OrNil()
+}
+
+// validateAttributeSchema checks that the subtype is valid and that the asset type defines a numeric
+// attribute with this name and subtype. Digital attributes and attributes with a value map hold states
+// instead of measurements, so they are not numeric. A missing asset type is reported for assetTypeField.
+func validateAttributeSchema(validationErr *ValidationError, assetTypeField string, assetTypeName string, assetType *api.AssetType, subtype string, attributeName string) {
+	if !validateSubtype(validationErr, subtype) {
+		return
+	}
+	if assetType == nil {
+		validationErr.Add(assetTypeField, "asset type %q doesn't exist", assetTypeName)
+		return
+	}
+	var subtypes []string
+	for _, attribute := range assetType.Attributes {
+		if attribute.Name != attributeName {
+			continue
+		}
+		if string(attribute.Subtype) != subtype {
+			subtypes = append(subtypes, string(attribute.Subtype))
+			continue
+		}
+		if attribute.GetIsDigital() || len(attribute.GetMap()) > 0 {
+			validationErr.Add("attributeName", "attribute %q of asset type %q is not numeric", attributeName, assetTypeName)
+		}
+		return
+	}
+	if len(subtypes) > 0 {
+		validationErr.Add("subtype", "attribute %q of asset type %q has subtype %s, not %s", attributeName, assetTypeName, strings.Join(subtypes, ", "), subtype)
+		return
+	}
+	validationErr.Add("attributeName", "attribute %q doesn't exist in asset type %q", attributeName, assetTypeName)
+}
+
+// validateSubtype checks that the subtype is one of the data subtypes of Eliona.
+func validateSubtype(validationErr *ValidationError, subtype string) bool {
+	if !api.DataSubtype(subtype).IsValid() {
+		validationErr.Add("subtype", "must be one of %s, %s, %s, %s or %s",
+			api.SUBTYPE_INPUT, api.SUBTYPE_INFO, api.SUBTYPE_STATUS, api.SUBTYPE_OUTPUT, api.SUBTYPE_PROPERTY)
+		return false
+	}
+	return true
+}
+
+// MaxPrecision is the highest number of decimal places values can be rounded to. A float64 holds
+// no more than 15 significant decimal digits, so more decimal places wouldn't change the value.
+const MaxPrecision = 15
+
+// validatePrecision checks that the number of decimal places is between 0 and MaxPrecision.
+func validatePrecision(validationErr *ValidationError, precision null.Int32) {
+	if precision.Valid && (precision.Int32 < 0 || precision.Int32 > MaxPrecision) {
+		validationErr.Add("precision", "must be between 0 and %d", MaxPrecision)
+	}
+}
+
+// LintAssetAttributes checks the configured asset attributes against the current attribute schemas in
+// Eliona and returns the ones that no longer match, e.g. because the asset or the attribute was removed.
+func LintAssetAttributes(ctx context.Context, configId int32, assetId int32, subtype string, attributeName string) ([]apiserver.AssetAttributeLint, error) {
+	mods := selectAssetAttributesMods(configId, assetId, subtype, attributeName)
+	dbAssetAttributes, err := appdb.AssetAttributes(mods...).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lints := []apiserver.AssetAttributeLint{}
+	assetTypes := map[string]*api.AssetType{}
+	for _, dbAssetAttribute := range dbAssetAttributes {
+		validationErr := &ValidationError{}
+		apiAsset, err := eliona.GetAsset(dbAssetAttribute)
+		if err != nil {
+			return nil, fmt.Errorf("getting asset %d from Eliona: %w", dbAssetAttribute.AssetID, err)
+		}
+		if apiAsset == nil {
+			validationErr.Add("assetId", "asset %d doesn't exist", dbAssetAttribute.AssetID)
+		} else {
+			assetType, ok := assetTypes[apiAsset.AssetType]
+			if !ok {
+				assetType, err = eliona.GetAssetType(apiAsset.AssetType)
+				if err != nil {
+					return nil, err
+				}
+				assetTypes[apiAsset.AssetType] = assetType
+			}
+			validateAttributeSchema(validationErr, "assetId", apiAsset.AssetType, assetType, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName)
+		}
+		if len(validationErr.Fields) == 0 {
+			continue
+		}
+		lint := apiserver.AssetAttributeLint{
+			ConfigId:      int32(dbAssetAttribute.ConfigID),
+			AssetId:       dbAssetAttribute.AssetID,
+			Subtype:       dbAssetAttribute.Subtype,
+			AttributeName: dbAssetAttribute.AttributeName,
+		}
+		for _, field := range validationErr.Fields {
+			lint.Errors = append(lint.Errors, apiserver.FieldError{Field: field.Field, Message: field.Message})
+		}
+		lints = append(lints, lint)
+	}
+	return lints, nil
+}
+
+// RenderReferences renders the references of the asset attributes of the configuration again with its
+// current templates, e.g. after a template changed. Given references are kept. References that would be
+// used by another asset attribute afterwards aren't changed. The asset attributes whose references changed
+// or couldn't be rendered are returned. With dryRun, nothing is stored.
+func RenderReferences(ctx context.Context, configId int64, dryRun bool) ([]apiserver.RenderedReference, error) {
+	renderer, err := newReferenceRenderer(ctx, configId)
+	if err != nil {
+		return nil, err
+	}
+	mods := []qm.QueryMod{
+		appdb.AssetAttributeWhere.ConfigID.EQ(configId),
+		qm.OrderBy(appdb.AssetAttributeColumns.AssetID + ", " + appdb.AssetAttributeColumns.Subtype + ", " + appdb.AssetAttributeColumns.AttributeName),
+	}
+
+	// The assets are fetched from Eliona before the configuration is locked, so the lock is held for the
+	// writes only.
+	dbAssetAttributes, err := appdb.AssetAttributes(mods...).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching asset attributes: %v", err)
+	}
+	var renderings []*referenceRendering
+	for _, dbAssetAttribute := range dbAssetAttributes {
+		rendering, err := renderAssetAttributeReferences(renderer, dbAssetAttribute)
+		if err != nil {
+			return nil, err
+		}
+		if rendering != nil {
+			renderings = append(renderings, rendering)
+		}
+	}
+	if dryRun {
+		resolveRenderingCollisions(dbAssetAttributes, renderings)
+		return renderedReferences(renderings), nil
+	}
+
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if err := lockReferences(ctx, tx, configId); err != nil {
+		return nil, err
+	}
+	dbAssetAttributes, err = appdb.AssetAttributes(mods...).All(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching asset attributes: %v", err)
+	}
+	renderings = unchangedRenderings(dbAssetAttributes, renderings)
+	resolveRenderingCollisions(dbAssetAttributes, renderings)
+	for _, rendering := range renderings {
+		if !rendering.store() {
+			continue
+		}
+		dbAssetAttribute := rendering.dbAssetAttribute
+		if rendering.changed() {
+			dbAssetAttribute.DeviceReference = rendering.deviceReference
+			dbAssetAttribute.RegisterReference = rendering.registerReference
+		}
+		dbAssetAttribute.DeviceReferenceRendered = rendering.deviceRendered
+		dbAssetAttribute.RegisterReferenceRendered = rendering.registerRendered
+		_, err = dbAssetAttribute.Update(ctx, tx, boil.Whitelist(
+			appdb.AssetAttributeColumns.DeviceReference,
+			appdb.AssetAttributeColumns.RegisterReference,
+			appdb.AssetAttributeColumns.DeviceReferenceRendered,
+			appdb.AssetAttributeColumns.RegisterReferenceRendered,
+		))
+		if err != nil {
+			return nil, fmt.Errorf("updating asset attribute: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %v", err)
+	}
+	return renderedReferences(renderings), nil
+}
+
+// assetAttributeKey identifies an asset attribute within a configuration.
+type assetAttributeKey struct {
+	assetId       int32
+	subtype       string
+	attributeName string
+}
+
+func assetAttributeKeyOf(dbAssetAttribute *appdb.AssetAttribute) assetAttributeKey {
+	return assetAttributeKey{
+		assetId:       dbAssetAttribute.AssetID,
+		subtype:       dbAssetAttribute.Subtype,
+		attributeName: dbAssetAttribute.AttributeName,
+	}
+}
+
+// unchangedRenderings returns the renderings of the asset attributes read again under the lock. Renderings
+// of asset attributes deleted or changed since they were rendered are dropped, they are rendered again the
+// next time.
+func unchangedRenderings(dbAssetAttributes []*appdb.AssetAttribute, renderings []*referenceRendering) []*referenceRendering {
+	current := make(map[assetAttributeKey]*appdb.AssetAttribute)
+	for _, dbAssetAttribute := range dbAssetAttributes {
+		current[assetAttributeKeyOf(dbAssetAttribute)] = dbAssetAttribute
+	}
+	var unchanged []*referenceRendering
+	for _, rendering := range renderings {
+		rendered := rendering.dbAssetAttribute
+		dbAssetAttribute := current[assetAttributeKeyOf(rendered)]
+		if dbAssetAttribute == nil || referenceKeyOf(dbAssetAttribute) != referenceKeyOf(rendered) ||
+			dbAssetAttribute.DeviceReferenceRendered != rendered.DeviceReferenceRendered ||
+			dbAssetAttribute.RegisterReferenceRendered != rendered.RegisterReferenceRendered {
+			continue
+		}
+		rendering.dbAssetAttribute = dbAssetAttribute
+		unchanged = append(unchanged, rendering)
+	}
+	return unchanged
+}
+
+// resolveRenderingCollisions applies the changed references to the usage of the references by the asset
+// attributes. References used more than once afterwards are reverted, until no change leads to a collision.
+func resolveRenderingCollisions(dbAssetAttributes []*appdb.AssetAttribute, renderings []*referenceRendering) {
+	usage := make(map[referenceKey]int)
+	for _, dbAssetAttribute := range dbAssetAttributes {
+		usage[referenceKeyOf(dbAssetAttribute)]++
+	}
+	for _, rendering := range renderings {
+		if rendering.changed() {
+			usage[rendering.oldKey()]--
+			usage[rendering.newKey()]++
+		}
+	}
+	for reverted := true; reverted; {
+		reverted = false
+		for _, rendering := range renderings {
+			if rendering.changed() && usage[rendering.newKey()] > 1 {
+				usage[rendering.newKey()]--
+				usage[rendering.oldKey()]++
+				rendering.collides = true
+				reverted = true
+			}
+		}
+	}
+}
+
+func renderedReferences(renderings []*referenceRendering) []apiserver.RenderedReference {
+	renderedReferences := []apiserver.RenderedReference{}
+	for _, rendering := range renderings {
+		if rendering.report() {
+			renderedReferences = append(renderedReferences, rendering.renderedReference())
+		}
+	}
+	return renderedReferences
+}
+
+// referenceRendering holds the references of an asset attribute rendered again.
+type referenceRendering struct {
+	dbAssetAttribute  *appdb.AssetAttribute
+	deviceReference   string
+	registerReference string
+	deviceRendered    null.Bool
+	registerRendered  null.Bool
+	errors            []apiserver.FieldError
+	collides          bool
+}
+
+// renderAssetAttributeReferences renders the references of the asset attribute again. Nil is returned, if
+// both references were given explicitly.
+func renderAssetAttributeReferences(renderer *referenceRenderer, dbAssetAttribute *appdb.AssetAttribute) (*referenceRendering, error) {
+	rendering := &referenceRendering{
+		dbAssetAttribute:  dbAssetAttribute,
+		deviceReference:   dbAssetAttribute.DeviceReference,
+		registerReference: dbAssetAttribute.RegisterReference,
+		deviceRendered:    dbAssetAttribute.DeviceReferenceRendered,
+		registerRendered:  dbAssetAttribute.RegisterReferenceRendered,
+	}
+	if rendering.deviceRendered.Valid && !rendering.deviceRendered.Bool && rendering.registerRendered.Valid && !rendering.registerRendered.Bool {
+		return nil, nil
+	}
+	apiAsset, err := eliona.GetAsset(dbAssetAttribute)
+	if err != nil {
+		return nil, fmt.Errorf("getting asset %d from Eliona: %w", dbAssetAttribute.AssetID, err)
+	}
+	if apiAsset == nil {
+		rendering.errors = []apiserver.FieldError{{Field: "assetId", Message: fmt.Sprintf("asset %d doesn't exist", dbAssetAttribute.AssetID)}}
+		return rendering, nil
+	}
+
+	// Asset attributes stored before the templates existed don't know if their references were given.
+	// References equal to the former default are taken as rendered.
+	if !rendering.deviceRendered.Valid {
+		rendering.deviceRendered = null.BoolFrom(dbAssetAttribute.DeviceReference == normalizeReference(apiAsset.GlobalAssetIdentifier))
+	}
+	if !rendering.registerRendered.Valid {
+		rendering.registerRendered = null.BoolFrom(dbAssetAttribute.RegisterReference == normalizeReference(dbAssetAttribute.AttributeName))
+	}
+	deviceReference, registerReference, err := renderer.render(dbAssetAttribute, apiAsset, rendering.deviceRendered.Bool, rendering.registerRendered.Bool)
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		for _, field := range validationErr.Fields {
+			rendering.errors = append(rendering.errors, apiserver.FieldError{Field: field.Field, Message: field.Message})
+		}
+		return rendering, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rendering.deviceReference = deviceReference
+	rendering.registerReference = registerReference
+	return rendering, nil
+}
+
+func (r *referenceRendering) oldKey() referenceKey {
+	return referenceKeyOf(r.dbAssetAttribute)
+}
+
+func (r *referenceRendering) newKey() referenceKey {
+	return referenceKey{
+		configId:          r.dbAssetAttribute.ConfigID,
+		deviceReference:   r.deviceReference,
+		registerReference: r.registerReference,
+	}
+}
+
+func (r *referenceRendering) changed() bool {
+	return !r.collides && len(r.errors) == 0 && r.newKey() != r.oldKey()
+}
+
+func (r *referenceRendering) report() bool {
+	return r.changed() || r.collides || len(r.errors) > 0
+}
+
+// store reports if the asset attribute has to be updated, either because its references changed or
+// because it didn't know yet whether its references were rendered.
+func (r *referenceRendering) store() bool {
+	if len(r.errors) > 0 {
+		return false
+	}
+	return r.changed() || !r.dbAssetAttribute.DeviceReferenceRendered.Valid || !r.dbAssetAttribute.RegisterReferenceRendered.Valid
+}
+
+func (r *referenceRendering) renderedReference() apiserver.RenderedReference {
+	renderedReference := apiserver.RenderedReference{
+		AssetId:                   r.dbAssetAttribute.AssetID,
+		Subtype:                   r.dbAssetAttribute.Subtype,
+		AttributeName:             r.dbAssetAttribute.AttributeName,
+		PreviousDeviceReference:   r.dbAssetAttribute.DeviceReference,
+		PreviousRegisterReference: r.dbAssetAttribute.RegisterReference,
+		Errors:                    r.errors,
+	}
+	if r.collides {
+		renderedReference.Errors = append(renderedReference.Errors, apiserver.FieldError{
+			Field:   "deviceReference",
+			Message: fmt.Sprintf("device reference %q and register reference %q would be used by another asset attribute", r.deviceReference, r.registerReference),
+		})
+	}
+	if r.changed() {
+		renderedReference.DeviceReference = common.Ptr(r.deviceReference)
+		renderedReference.RegisterReference = common.Ptr(r.registerReference)
+	}
+	return renderedReference
+}
+
 func UpdateAssetAttributeLatestTimestamp(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute, latestTimestamp time.Time) error {
 	dbAssetAttribute.LatestTS = latestTimestamp
 	_, err := dbAssetAttribute.UpdateG(ctx, boil.Whitelist(appdb.AssetAttributeColumns.LatestTS))
 	return err
 }
 
+// UpdateAssetAttributeSuccess records a successful attempt to send the data of the asset attribute.
+func UpdateAssetAttributeSuccess(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute) error {
+	now := time.Now()
+	dbAssetAttribute.LastAttemptTS = null.TimeFrom(now)
+	dbAssetAttribute.LastSuccessTS = null.TimeFrom(now)
+	dbAssetAttribute.LastError = null.String{}
+	dbAssetAttribute.ConsecutiveFailures = 0
+	_, err := dbAssetAttribute.UpdateG(ctx, boil.Whitelist(
+		appdb.AssetAttributeColumns.LastAttemptTS,
+		appdb.AssetAttributeColumns.LastSuccessTS,
+		appdb.AssetAttributeColumns.LastError,
+		appdb.AssetAttributeColumns.ConsecutiveFailures,
+	))
+	return err
+}
+
+// UpdateAssetAttributeFailure records a failed attempt to send the data of the asset attribute.
+func UpdateAssetAttributeFailure(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute, attemptErr error) error {
+	dbAssetAttribute.LastAttemptTS = null.TimeFrom(time.Now())
+	dbAssetAttribute.LastError = null.StringFrom(attemptErr.Error())
+	dbAssetAttribute.ConsecutiveFailures++
+	_, err := dbAssetAttribute.UpdateG(ctx, boil.Whitelist(
+		appdb.AssetAttributeColumns.LastAttemptTS,
+		appdb.AssetAttributeColumns.LastError,
+		appdb.AssetAttributeColumns.ConsecutiveFailures,
+	))
+	return err
+}
+
+// GetDbAssetAttributes returns the asset attributes of the configuration sent to Zevvy. Retired asset
+// attributes are left out.
 func GetDbAssetAttributes(ctx context.Context, configId int64) (dbAssetAttributes []*appdb.AssetAttribute, err error) {
-	return appdb.AssetAttributes(appdb.AssetAttributeWhere.ConfigID.EQ(int32(configId))).AllG(ctx)
+	return appdb.AssetAttributes(
+		appdb.AssetAttributeWhere.ConfigID.EQ(configId),
+		appdb.AssetAttributeWhere.RetiredTS.IsNull(),
+	).AllG(ctx)
 }
 
 func GetAssetAttributes(ctx context.Context, configId int32, assetId int32, subtype string, attributeName string) ([]*apiserver.AssetAttribute, error) {
@@ -101,7 +543,7 @@ func GetAssetAttributes(ctx context.Context, configId int32, assetId int32, subt
 func selectAssetAttributesMods(configId int32, assetId int32, subtype string, attributeName string) []qm.QueryMod {
 	var mods []qm.QueryMod
 	if configId > 0 {
-		mods = append(mods, appdb.AssetAttributeWhere.ConfigID.EQ(configId))
+		mods = append(mods, appdb.AssetAttributeWhere.ConfigID.EQ(int64(configId)))
 	}
 	if assetId > 0 {
 		mods = append(mods, appdb.AssetAttributeWhere.AssetID.EQ(assetId))
@@ -116,11 +558,61 @@ func selectAssetAttributesMods(configId int32, assetId int32, subtype string, at
 }
 
 func DeleteAssetAttributes(ctx context.Context, configId int32, assetId int32, subtype string, attributeName string) error {
-	mods := selectAssetAttributesMods(configId, assetId, subtype, attributeName)
-	_, err := appdb.AssetAttributes(mods...).DeleteAllG(ctx)
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	dbAssetAttributes, err := appdb.AssetAttributes(selectAssetAttributesMods(configId, assetId, subtype, attributeName)...).All(ctx, tx)
 	if err != nil {
 		return err
 	}
+	for _, dbAssetAttribute := range dbAssetAttributes {
+		if err := deleteAssetAttribute(ctx, tx, dbAssetAttribute); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %v", err)
+	}
+	return nil
+}
+
+// deleteAssetAttribute deletes the asset attribute and the data belonging to it. Queued measurements, dead
+// letters and backfills can no longer be sent without the asset attribute.
+func deleteAssetAttribute(ctx context.Context, exec boil.ContextExecutor, dbAssetAttribute *appdb.AssetAttribute) error {
+	_, err := appdb.DeadLetters(
+		appdb.DeadLetterWhere.ConfigID.EQ(dbAssetAttribute.ConfigID),
+		appdb.DeadLetterWhere.AssetID.EQ(dbAssetAttribute.AssetID),
+		appdb.DeadLetterWhere.Subtype.EQ(dbAssetAttribute.Subtype),
+		appdb.DeadLetterWhere.AttributeName.EQ(dbAssetAttribute.AttributeName),
+	).DeleteAll(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("deleting dead letters: %v", err)
+	}
+	_, err = appdb.Backfills(
+		appdb.BackfillWhere.ConfigID.EQ(dbAssetAttribute.ConfigID),
+		appdb.BackfillWhere.AssetID.EQ(dbAssetAttribute.AssetID),
+		appdb.BackfillWhere.Subtype.EQ(dbAssetAttribute.Subtype),
+		appdb.BackfillWhere.AttributeName.EQ(dbAssetAttribute.AttributeName),
+	).DeleteAll(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("deleting backfills: %v", err)
+	}
+	_, err = appdb.Outboxes(
+		appdb.OutboxWhere.ConfigID.EQ(dbAssetAttribute.ConfigID),
+		appdb.OutboxWhere.AssetID.EQ(dbAssetAttribute.AssetID),
+		appdb.OutboxWhere.Subtype.EQ(dbAssetAttribute.Subtype),
+		appdb.OutboxWhere.AttributeName.EQ(dbAssetAttribute.AttributeName),
+	).DeleteAll(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("deleting outbox: %v", err)
+	}
+	if _, err := dbAssetAttribute.Delete(ctx, exec); err != nil {
+		return fmt.Errorf("deleting asset attribute: %v", err)
+	}
 	return nil
 }
 
@@ -128,7 +620,7 @@ func dbAssetAttributeFromApiAssetAttribute(apiAssetAttribute *apiserver.AssetAtt
 	var dbAssetAttribute *appdb.AssetAttribute
 	if apiAssetAttribute != nil {
 		dbAssetAttribute = new(appdb.AssetAttribute)
-		dbAssetAttribute.ConfigID = apiAssetAttribute.ConfigId
+		dbAssetAttribute.ConfigID = int64(apiAssetAttribute.ConfigId)
 		dbAssetAttribute.AssetID = apiAssetAttribute.AssetId
 		dbAssetAttribute.Subtype = apiAssetAttribute.Subtype
 		dbAssetAttribute.AttributeName = apiAssetAttribute.AttributeName
@@ -138,6 +630,7 @@ func dbAssetAttributeFromApiAssetAttribute(apiAssetAttribute *apiserver.AssetAtt
 		if apiAssetAttribute.LatestTimestamp == nil {
 			dbAssetAttribute.LatestTS = time.Now()
 		}
+		dbAssetAttribute.Precision = null.Int32FromPtr(apiAssetAttribute.Precision)
 	}
 	return dbAssetAttribute
 }
@@ -146,13 +639,21 @@ func apiAssetAttributeFromDbAssetAttribute(dbAssetAttribute *appdb.AssetAttribut
 	var apiAssetAttribute *apiserver.AssetAttribute
 	if dbAssetAttribute != nil {
 		apiAssetAttribute = new(apiserver.AssetAttribute)
-		apiAssetAttribute.ConfigId = dbAssetAttribute.ConfigID
+		apiAssetAttribute.ConfigId = int32(dbAssetAttribute.ConfigID)
 		apiAssetAttribute.AssetId = dbAssetAttribute.AssetID
 		apiAssetAttribute.Subtype = dbAssetAttribute.Subtype
 		apiAssetAttribute.AttributeName = dbAssetAttribute.AttributeName
 		apiAssetAttribute.DeviceReference = common.Ptr(dbAssetAttribute.DeviceReference)
 		apiAssetAttribute.RegisterReference = common.Ptr(dbAssetAttribute.RegisterReference)
 		apiAssetAttribute.LatestTimestamp = common.Ptr(dbAssetAttribute.LatestTS)
+		apiAssetAttribute.Precision = dbAssetAttribute.Precision.Ptr()
+		apiAssetAttribute.LastAttemptTimestamp = dbAssetAttribute.LastAttemptTS.Ptr()
+		apiAssetAttribute.LastSuccessTimestamp = dbAssetAttribute.LastSuccessTS.Ptr()
+		apiAssetAttribute.LastError = dbAssetAttribute.LastError.Ptr()
+		apiAssetAttribute.ConsecutiveFailures = common.Ptr(dbAssetAttribute.ConsecutiveFailures)
+		apiAssetAttribute.TotalSent = common.Ptr(dbAssetAttribute.TotalSent)
+		apiAssetAttribute.RuleId = dbAssetAttribute.RuleID.Ptr()
+		apiAssetAttribute.RetiredTimestamp = dbAssetAttribute.RetiredTS.Ptr()
 	}
 	return apiAssetAttribute
 }
@@ -0,0 +1,192 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"zevvy/apiserver"
+	"zevvy/appdb"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	BackfillStatusPending  = "pending"
+	BackfillStatusRunning  = "running"
+	BackfillStatusFinished = "finished"
+	BackfillStatusFailed   = "failed"
+)
+
+// CreateBackfills creates a backfill job for each configured asset attribute matching the given backfill.
+// The job queues all data from the start timestamp up to the latest timestamp already read from Eliona.
+func CreateBackfills(ctx context.Context, apiBackfill apiserver.Backfill) ([]*apiserver.Backfill, error) {
+	mods := selectAssetAttributesMods(apiBackfill.ConfigId, common.Val(apiBackfill.AssetId), common.Val(apiBackfill.Subtype), common.Val(apiBackfill.AttributeName))
+	dbAssetAttributes, err := appdb.AssetAttributes(mods...).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching asset attributes: %v", err)
+	}
+	if len(dbAssetAttributes) == 0 {
+		return nil, ErrNotFound
+	}
+	var apiBackfills []*apiserver.Backfill
+	for _, dbAssetAttribute := range dbAssetAttributes {
+		dbBackfill := &appdb.Backfill{
+			ConfigID:      dbAssetAttribute.ConfigID,
+			AssetID:       dbAssetAttribute.AssetID,
+			Subtype:       dbAssetAttribute.Subtype,
+			AttributeName: dbAssetAttribute.AttributeName,
+			StartTS:       apiBackfill.StartTimestamp,
+			EndTS:         dbAssetAttribute.LatestTS,
+			ProgressTS:    apiBackfill.StartTimestamp,
+			Status:        BackfillStatusPending,
+		}
+		if !dbBackfill.StartTS.Before(dbBackfill.EndTS) {
+			dbBackfill.ProgressTS = dbBackfill.EndTS
+			dbBackfill.Status = BackfillStatusFinished
+		}
+		if err := dbBackfill.InsertG(ctx, boil.Infer()); err != nil {
+			return nil, fmt.Errorf("inserting backfill: %v", err)
+		}
+		apiBackfills = append(apiBackfills, apiBackfillFromDbBackfill(dbBackfill))
+	}
+	return apiBackfills, nil
+}
+
+func GetBackfills(ctx context.Context, configId int32) ([]*apiserver.Backfill, error) {
+	var mods []qm.QueryMod
+	if configId > 0 {
+		mods = append(mods, appdb.BackfillWhere.ConfigID.EQ(int64(configId)))
+	}
+	mods = append(mods, qm.OrderBy(appdb.BackfillColumns.ID))
+	dbBackfills, err := appdb.Backfills(mods...).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var apiBackfills []*apiserver.Backfill
+	for _, dbBackfill := range dbBackfills {
+		apiBackfills = append(apiBackfills, apiBackfillFromDbBackfill(dbBackfill))
+	}
+	return apiBackfills, nil
+}
+
+func GetBackfill(ctx context.Context, backfillId int64) (*apiserver.Backfill, error) {
+	dbBackfill, err := appdb.FindBackfillG(ctx, backfillId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching backfill from database: %v", err)
+	}
+	return apiBackfillFromDbBackfill(dbBackfill), nil
+}
+
+func DeleteBackfill(ctx context.Context, backfillId int64) error {
+	count, err := appdb.Backfills(
+		appdb.BackfillWhere.ID.EQ(backfillId),
+	).DeleteAllG(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting backfill from database: %v", err)
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetDbOpenBackfills returns all backfill jobs which are not finished or failed yet.
+func GetDbOpenBackfills(ctx context.Context) ([]*appdb.Backfill, error) {
+	return appdb.Backfills(
+		appdb.BackfillWhere.Status.IN([]string{BackfillStatusPending, BackfillStatusRunning}),
+		qm.OrderBy(appdb.BackfillColumns.ID),
+	).AllG(ctx)
+}
+
+// GetDbAssetAttributeForBackfill returns the configured asset attribute the backfill job belongs to.
+func GetDbAssetAttributeForBackfill(ctx context.Context, dbBackfill *appdb.Backfill) (*appdb.AssetAttribute, error) {
+	dbAssetAttribute, err := appdb.AssetAttributes(
+		appdb.AssetAttributeWhere.ConfigID.EQ(dbBackfill.ConfigID),
+		appdb.AssetAttributeWhere.AssetID.EQ(dbBackfill.AssetID),
+		appdb.AssetAttributeWhere.Subtype.EQ(dbBackfill.Subtype),
+		appdb.AssetAttributeWhere.AttributeName.EQ(dbBackfill.AttributeName),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return dbAssetAttribute, err
+}
+
+// UpdateBackfillProgress stores that all data before the progress timestamp was queued in the outbox.
+// It is sent to Zevvy with the current data of the attribute. The job is finished as soon as the
+// progress reaches the end timestamp, even if queued data is still waiting to be sent.
+func UpdateBackfillProgress(ctx context.Context, dbBackfill *appdb.Backfill, progressTimestamp time.Time) error {
+	dbBackfill.ProgressTS = progressTimestamp
+	dbBackfill.Status = BackfillStatusRunning
+	if !dbBackfill.ProgressTS.Before(dbBackfill.EndTS) {
+		dbBackfill.Status = BackfillStatusFinished
+	}
+	dbBackfill.Error = null.String{}
+	dbBackfill.UpdatedAt = time.Now()
+	_, err := dbBackfill.UpdateG(ctx, boil.Whitelist(
+		appdb.BackfillColumns.ProgressTS,
+		appdb.BackfillColumns.Status,
+		appdb.BackfillColumns.Error,
+		appdb.BackfillColumns.UpdatedAt,
+	))
+	return err
+}
+
+// UpdateBackfillError stores the error occurred while running the backfill job. If the
+// job cannot be continued, it is marked as failed.
+func UpdateBackfillError(ctx context.Context, dbBackfill *appdb.Backfill, backfillErr error, failed bool) error {
+	if failed {
+		dbBackfill.Status = BackfillStatusFailed
+	}
+	dbBackfill.Error = null.StringFrom(backfillErr.Error())
+	dbBackfill.UpdatedAt = time.Now()
+	_, err := dbBackfill.UpdateG(ctx, boil.Whitelist(
+		appdb.BackfillColumns.Status,
+		appdb.BackfillColumns.Error,
+		appdb.BackfillColumns.UpdatedAt,
+	))
+	return err
+}
+
+func apiBackfillFromDbBackfill(dbBackfill *appdb.Backfill) *apiserver.Backfill {
+	var apiBackfill *apiserver.Backfill
+	if dbBackfill != nil {
+		apiBackfill = new(apiserver.Backfill)
+		apiBackfill.Id = common.Ptr(dbBackfill.ID)
+		apiBackfill.ConfigId = int32(dbBackfill.ConfigID)
+		apiBackfill.AssetId = common.Ptr(dbBackfill.AssetID)
+		apiBackfill.Subtype = common.Ptr(dbBackfill.Subtype)
+		apiBackfill.AttributeName = common.Ptr(dbBackfill.AttributeName)
+		apiBackfill.StartTimestamp = dbBackfill.StartTS
+		apiBackfill.EndTimestamp = common.Ptr(dbBackfill.EndTS)
+		apiBackfill.ProgressTimestamp = common.Ptr(dbBackfill.ProgressTS)
+		apiBackfill.Status = common.Ptr(dbBackfill.Status)
+		apiBackfill.Error = dbBackfill.Error.Ptr()
+		apiBackfill.CreatedAt = common.Ptr(dbBackfill.CreatedAt)
+		apiBackfill.UpdatedAt = common.Ptr(dbBackfill.UpdatedAt)
+	}
+	return apiBackfill
+}
@@ -20,6 +20,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 	"zevvy/apiserver"
 	"zevvy/appdb"
@@ -27,32 +29,249 @@ import (
 
 	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 var ErrNotFound = errors.New("not found")
 
+// ErrPreconditionFailed is returned if the configuration was changed since the version given in If-Match.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// ErrConflict is returned if a configuration can't be deleted with the requested mappings policy.
+var ErrConflict = errors.New("conflict")
+
+// Policies for the asset attributes of a deleted configuration.
+const (
+	MappingsRestrict = "restrict"
+	MappingsPurge    = "purge"
+	MappingsReassign = "reassign"
+)
+
+const (
+	LoginStatePending    = "pending"
+	LoginStateAuthorized = "authorized"
+	LoginStateDenied     = "denied"
+)
+
+// Auth modes define how tokens are obtained from Zevvy.
+const (
+	AuthModeDeviceCode        = "device_code"
+	AuthModeClientCredentials = "client_credentials"
+	AuthModeRefreshToken      = "refresh_token"
+)
+
+const (
+	// defaultRefreshInterval is used if the configuration defines no refresh interval.
+	defaultRefreshInterval = 60
+	// minPollInterval is the polling interval used if the authorization server doesn't define one (RFC 8628 3.5).
+	minPollInterval = 5
+	// slowDownIncrement is added to the polling interval on each slow_down response (RFC 8628 3.5).
+	slowDownIncrement = 5
+	// accessTokenRefreshMargin is the minimum time before expiry the access token is refreshed.
+	accessTokenRefreshMargin = time.Minute
+	// refreshTokenExpireWarning is the time before the refresh token expires the user is notified.
+	refreshTokenExpireWarning = 3 * 24 * time.Hour
+)
+
+// InsertConfig creates the configuration and returns it as stored, including the generated ID.
 func InsertConfig(ctx context.Context, config apiserver.Configuration) (apiserver.Configuration, error) {
 	dbConfig, err := dbConfigFromApiConfig(ctx, config)
 	if err != nil {
 		return apiserver.Configuration{}, fmt.Errorf("creating DB config from API config: %v", err)
 	}
-	if err := dbConfig.InsertG(ctx, boil.Infer()); err != nil {
+	dbConfig.ID = 0 // The ID is generated by the database.
+	if err := validateSecrets(&dbConfig); err != nil {
+		return apiserver.Configuration{}, err
+	}
+	if err := insertConfig(ctx, boil.GetContextDB(), &dbConfig, boil.Infer()); err != nil {
 		return apiserver.Configuration{}, fmt.Errorf("inserting DB config: %v", err)
 	}
-	return config, nil
+	return apiConfigFromDbConfig(&dbConfig)
+}
+
+// UpsertConfig replaces the configuration or creates it, if it doesn't exist. Secrets not given or
+// given masked keep their stored value. If ifMatch is set, the configuration is only replaced if
+// its ETag matches. Returns the configuration as stored and whether it was created.
+func UpsertConfig(ctx context.Context, config apiserver.Configuration, ifMatch string) (apiserver.Configuration, bool, error) {
+	return saveConfig(ctx, config, ifMatch, true)
+}
+
+// UpdateConfig replaces the existing configuration like UpsertConfig, but returns ErrNotFound if the
+// configuration doesn't exist.
+func UpdateConfig(ctx context.Context, config apiserver.Configuration, ifMatch string) (apiserver.Configuration, error) {
+	updatedConfig, _, err := saveConfig(ctx, config, ifMatch, false)
+	return updatedConfig, err
 }
 
-func UpsertConfig(ctx context.Context, config apiserver.Configuration) (apiserver.Configuration, error) {
+func saveConfig(ctx context.Context, config apiserver.Configuration, ifMatch string, create bool) (apiserver.Configuration, bool, error) {
 	dbConfig, err := dbConfigFromApiConfig(ctx, config)
 	if err != nil {
-		return apiserver.Configuration{}, fmt.Errorf("creating DB config from API config: %v", err)
+		return apiserver.Configuration{}, false, fmt.Errorf("creating DB config from API config: %v", err)
 	}
-	if err := dbConfig.UpsertG(ctx, true, []string{"id"}, boil.Blacklist("id"), boil.Infer()); err != nil {
-		return apiserver.Configuration{}, fmt.Errorf("inserting DB config: %v", err)
+
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return apiserver.Configuration{}, false, fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	storedConfig, err := appdb.Configurations(
+		appdb.ConfigurationWhere.ID.EQ(dbConfig.ID),
+		qm.For("update"),
+	).One(ctx, tx)
+	created := errors.Is(err, sql.ErrNoRows)
+	switch {
+	case created && !create:
+		return apiserver.Configuration{}, false, ErrNotFound
+	case created:
+		if ifMatch != "" {
+			return apiserver.Configuration{}, false, ErrPreconditionFailed
+		}
+		if err := validateSecrets(&dbConfig); err != nil {
+			return apiserver.Configuration{}, false, err
+		}
+		if err := insertConfig(ctx, tx, &dbConfig, boil.Infer()); err != nil {
+			return apiserver.Configuration{}, false, fmt.Errorf("inserting DB config: %v", err)
+		}
+		// The ID was given by the client, so the sequence must skip it for configurations created later.
+		_, err := queries.Raw(`select setval(pg_get_serial_sequence('zevvy.configuration', 'id'), greatest((select max(id) from zevvy.configuration), 1))`).ExecContext(ctx, tx)
+		if err != nil {
+			return apiserver.Configuration{}, false, fmt.Errorf("updating ID sequence: %v", err)
+		}
+	case err != nil:
+		return apiserver.Configuration{}, false, fmt.Errorf("fetching config from database: %v", err)
+	default:
+		if !etagMatches(ifMatch, storedConfig.Version) {
+			return apiserver.Configuration{}, false, ErrPreconditionFailed
+		}
+		keepStoredConfig(&dbConfig, storedConfig, config)
+		if err := validateSecrets(&dbConfig); err != nil {
+			return apiserver.Configuration{}, false, err
+		}
+		dbConfig.Version = storedConfig.Version + 1
+		if err := updateConfig(ctx, tx, &dbConfig, boil.Infer()); err != nil {
+			return apiserver.Configuration{}, false, fmt.Errorf("updating DB config: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apiserver.Configuration{}, false, fmt.Errorf("committing transaction: %v", err)
+	}
+	savedConfig, err := apiConfigFromDbConfig(&dbConfig)
+	return savedConfig, created, err
+}
+
+// keepStoredConfig takes the values from the stored configuration the request can't change: write-only
+// secrets not given or given masked, the state of the login process and the app's runtime state. If the
+// credentials changed, the login process starts again.
+func keepStoredConfig(dbConfig *appdb.Configuration, storedConfig *appdb.Configuration, config apiserver.Configuration) {
+	if config.ClientSecret == "" || config.ClientSecret == maskSecret(storedConfig.ClientSecret) {
+		dbConfig.ClientSecret = storedConfig.ClientSecret
+	}
+	refreshTokenGiven := config.RefreshToken != nil && *config.RefreshToken != "" &&
+		*config.RefreshToken != maskSecret(storedConfig.RefreshToken.String)
+	if !refreshTokenGiven {
+		dbConfig.RefreshToken = storedConfig.RefreshToken
+	}
+	dbConfig.Active = storedConfig.Active
+	if !dbConfig.UserID.Valid {
+		dbConfig.UserID = storedConfig.UserID
+		dbConfig.ProjectID = storedConfig.ProjectID
+	}
+
+	credentialsChanged := refreshTokenGiven ||
+		dbConfig.AuthRootURL != storedConfig.AuthRootURL ||
+		dbConfig.ClientID != storedConfig.ClientID ||
+		dbConfig.ClientSecret != storedConfig.ClientSecret ||
+		dbConfig.AuthMode != storedConfig.AuthMode
+	if credentialsChanged {
+		if !refreshTokenGiven {
+			dbConfig.RefreshToken = null.String{}
+		}
+		return // The login state is left empty, so a new login starts.
+	}
+
+	dbConfig.DeviceCode = storedConfig.DeviceCode
+	dbConfig.VerificationURI = storedConfig.VerificationURI
+	dbConfig.VerificationURIExpire = storedConfig.VerificationURIExpire
+	dbConfig.VerificationInterval = storedConfig.VerificationInterval
+	dbConfig.NextPollTS = storedConfig.NextPollTS
+	dbConfig.AccessToken = storedConfig.AccessToken
+	dbConfig.AccessTokenExpire = storedConfig.AccessTokenExpire
+	dbConfig.RefreshTokenExpire = storedConfig.RefreshTokenExpire
+	dbConfig.RefreshExpireNotified = storedConfig.RefreshExpireNotified
+	// A denied login starts again, when the configuration is updated.
+	if storedConfig.LoginState.String != LoginStateDenied {
+		dbConfig.LoginState = storedConfig.LoginState
 	}
-	return config, nil
+}
+
+// MergeConfig returns the configuration with the fields given in the patch. All other fields keep
+// their values.
+func MergeConfig(config apiserver.Configuration, patch apiserver.Configuration) apiserver.Configuration {
+	if patch.AuthRootUrl != "" {
+		config.AuthRootUrl = patch.AuthRootUrl
+	}
+	if patch.ApiRootUrl != "" {
+		config.ApiRootUrl = patch.ApiRootUrl
+	}
+	if patch.ClientId != "" {
+		config.ClientId = patch.ClientId
+	}
+	if patch.ClientSecret != "" {
+		config.ClientSecret = patch.ClientSecret
+	}
+	if patch.AuthMode != nil {
+		config.AuthMode = patch.AuthMode
+	}
+	if patch.RefreshToken != nil {
+		config.RefreshToken = patch.RefreshToken
+	}
+	if patch.Enable != nil {
+		config.Enable = patch.Enable
+	}
+	if patch.RefreshInterval != nil {
+		config.RefreshInterval = patch.RefreshInterval
+	}
+	if patch.RequestTimeout != nil {
+		config.RequestTimeout = patch.RequestTimeout
+	}
+	if patch.BatchSize != nil {
+		config.BatchSize = patch.BatchSize
+	}
+	if patch.DeviceReferenceTemplate != nil {
+		config.DeviceReferenceTemplate = patch.DeviceReferenceTemplate
+	}
+	if patch.RegisterReferenceTemplate != nil {
+		config.RegisterReferenceTemplate = patch.RegisterReferenceTemplate
+	}
+	return config
+}
+
+// ETag returns the entity tag of the configuration version.
+func ETag(version int32) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// etagMatches checks the version against the entity tags of an If-Match header. An empty header
+// matches all versions.
+func etagMatches(ifMatch string, version int32) bool {
+	if ifMatch == "" {
+		return true
+	}
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == ETag(version) {
+			return true
+		}
+	}
+	return false
 }
 
 func GetConfig(ctx context.Context, configID int64) (apiserver.Configuration, error) {
@@ -80,18 +299,186 @@ func GetDbConfig(ctx context.Context, configID int64) (*appdb.Configuration, err
 	return dbConfig, nil
 }
 
-func DeleteConfig(ctx context.Context, configID int64) error {
-	count, err := appdb.Configurations(
+// DeleteConfig deletes the configuration. The mappings policy decides what happens to its asset attributes,
+// mapping rules and the queued measurements, backfills and dead letters belonging to them: MappingsRestrict
+// refuses to delete a configuration that still has asset attributes or mapping rules, MappingsPurge deletes
+// them and MappingsReassign moves them to the configuration reassignTo. ErrConflict is returned if the
+// policy can't be applied. The returned preview lists the affected data. With dryRun nothing is changed.
+func DeleteConfig(ctx context.Context, configID int64, mappings string, reassignTo int64, dryRun bool) (apiserver.DeletionPreview, error) {
+	preview := apiserver.DeletionPreview{Mappings: mappings}
+	validationErr := &ValidationError{}
+	switch mappings {
+	case MappingsRestrict, MappingsPurge:
+		if reassignTo != 0 {
+			validationErr.Add("reassignTo", "is only allowed with mappings %q", MappingsReassign)
+		}
+	case MappingsReassign:
+		preview.ReassignTo = &reassignTo
+		if reassignTo == 0 {
+			validationErr.Add("reassignTo", "is required with mappings %q", MappingsReassign)
+		} else if reassignTo == configID {
+			validationErr.Add("reassignTo", "must be another configuration")
+		}
+	default:
+		validationErr.Add("mappings", "must be one of %q, %q or %q", MappingsRestrict, MappingsPurge, MappingsReassign)
+	}
+	if err := validationErr.OrNil(); err != nil {
+		return preview, err
+	}
+
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return preview, fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	dbConfig, err := appdb.Configurations(
 		appdb.ConfigurationWhere.ID.EQ(configID),
-	).DeleteAllG(ctx)
+		qm.For("update"),
+	).One(ctx, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return preview, ErrNotFound
+	}
+	if err != nil {
+		return preview, fmt.Errorf("fetching config from database: %v", err)
+	}
+	if mappings == MappingsReassign {
+		err := lockReferences(ctx, tx, reassignTo)
+		if errors.Is(err, ErrNotFound) {
+			validationErr.Add("reassignTo", "configuration %d doesn't exist", reassignTo)
+			return preview, validationErr
+		}
+		if err != nil {
+			return preview, fmt.Errorf("checking target config: %v", err)
+		}
+	}
+
+	if err := previewDeletion(ctx, tx, dbConfig.ID, &preview); err != nil {
+		return preview, err
+	}
+	if dryRun {
+		return preview, nil
+	}
+	switch {
+	case mappings == MappingsRestrict && (len(preview.AssetAttributes) > 0 || preview.MappingRules > 0):
+		return preview, ErrConflict
+	case mappings == MappingsReassign && len(preview.Conflicts) > 0:
+		return preview, ErrConflict
+	case mappings == MappingsReassign:
+		err = reassignConfigData(ctx, tx, dbConfig.ID, reassignTo)
+	default:
+		err = purgeConfigData(ctx, tx, dbConfig.ID)
+	}
 	if err != nil {
-		return fmt.Errorf("deleting config from database: %v", err)
+		return preview, err
 	}
-	if count > 1 {
-		return fmt.Errorf("shouldn't happen: deleted more (%v) configs by ID", count)
+	if _, err := dbConfig.Delete(ctx, tx); err != nil {
+		return preview, fmt.Errorf("deleting config from database: %v", err)
 	}
-	if count == 0 {
-		return ErrNotFound
+	if err := tx.Commit(); err != nil {
+		return preview, fmt.Errorf("committing transaction: %v", err)
+	}
+	return preview, nil
+}
+
+// previewDeletion fills the preview with the data of the configuration. If the asset attributes are
+// reassigned, the ones already mapped by the target configuration are listed as conflicts.
+func previewDeletion(ctx context.Context, exec boil.ContextExecutor, configID int64, preview *apiserver.DeletionPreview) error {
+	dbAssetAttributes, err := appdb.AssetAttributes(
+		appdb.AssetAttributeWhere.ConfigID.EQ(configID),
+		qm.OrderBy(appdb.AssetAttributeColumns.AssetID),
+	).All(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("fetching asset attributes: %v", err)
+	}
+	for _, dbAssetAttribute := range dbAssetAttributes {
+		preview.AssetAttributes = append(preview.AssetAttributes, *apiAssetAttributeFromDbAssetAttribute(dbAssetAttribute))
+		if preview.ReassignTo == nil {
+			continue
+		}
+		conflict, err := appdb.AssetAttributeExists(ctx, exec, *preview.ReassignTo, dbAssetAttribute.AssetID, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName)
+		if err != nil {
+			return fmt.Errorf("checking target asset attribute: %v", err)
+		}
+		if !conflict {
+			// The measurements would be merged into one register in Zevvy.
+			conflict, err = appdb.AssetAttributes(
+				appdb.AssetAttributeWhere.ConfigID.EQ(*preview.ReassignTo),
+				appdb.AssetAttributeWhere.DeviceReference.EQ(dbAssetAttribute.DeviceReference),
+				appdb.AssetAttributeWhere.RegisterReference.EQ(dbAssetAttribute.RegisterReference),
+			).Exists(ctx, exec)
+			if err != nil {
+				return fmt.Errorf("checking target references: %v", err)
+			}
+		}
+		if conflict {
+			preview.Conflicts = append(preview.Conflicts, *apiAssetAttributeFromDbAssetAttribute(dbAssetAttribute))
+		}
+	}
+
+	outbox, err := appdb.Outboxes(appdb.OutboxWhere.ConfigID.EQ(configID)).Count(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("counting outbox: %v", err)
+	}
+	backfills, err := appdb.Backfills(appdb.BackfillWhere.ConfigID.EQ(configID)).Count(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("counting backfills: %v", err)
+	}
+	deadLetters, err := appdb.DeadLetters(appdb.DeadLetterWhere.ConfigID.EQ(configID)).Count(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("counting dead letters: %v", err)
+	}
+	mappingRules, err := appdb.MappingRules(appdb.MappingRuleWhere.ConfigID.EQ(configID)).Count(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("counting mapping rules: %v", err)
+	}
+	preview.Outbox = int32(outbox)
+	preview.Backfills = int32(backfills)
+	preview.DeadLetters = int32(deadLetters)
+	preview.MappingRules = int32(mappingRules)
+	return nil
+}
+
+// purgeConfigData deletes the asset attributes of the configuration and the data belonging to them.
+func purgeConfigData(ctx context.Context, exec boil.ContextExecutor, configID int64) error {
+	if _, err := appdb.DeadLetters(appdb.DeadLetterWhere.ConfigID.EQ(configID)).DeleteAll(ctx, exec); err != nil {
+		return fmt.Errorf("deleting dead letters: %v", err)
+	}
+	if _, err := appdb.Backfills(appdb.BackfillWhere.ConfigID.EQ(configID)).DeleteAll(ctx, exec); err != nil {
+		return fmt.Errorf("deleting backfills: %v", err)
+	}
+	if _, err := appdb.Outboxes(appdb.OutboxWhere.ConfigID.EQ(configID)).DeleteAll(ctx, exec); err != nil {
+		return fmt.Errorf("deleting outbox: %v", err)
+	}
+	if _, err := appdb.AssetAttributes(appdb.AssetAttributeWhere.ConfigID.EQ(configID)).DeleteAll(ctx, exec); err != nil {
+		return fmt.Errorf("deleting asset attributes: %v", err)
+	}
+	if _, err := appdb.MappingRules(appdb.MappingRuleWhere.ConfigID.EQ(configID)).DeleteAll(ctx, exec); err != nil {
+		return fmt.Errorf("deleting mapping rules: %v", err)
+	}
+	return nil
+}
+
+// reassignConfigData moves the asset attributes of the configuration and the data belonging to them to
+// the target configuration.
+func reassignConfigData(ctx context.Context, exec boil.ContextExecutor, configID int64, targetID int64) error {
+	cols := appdb.M{appdb.AssetAttributeColumns.ConfigID: targetID}
+	if _, err := appdb.AssetAttributes(appdb.AssetAttributeWhere.ConfigID.EQ(configID)).UpdateAll(ctx, exec, cols); err != nil {
+		return fmt.Errorf("reassigning asset attributes: %v", err)
+	}
+	if _, err := appdb.Outboxes(appdb.OutboxWhere.ConfigID.EQ(configID)).UpdateAll(ctx, exec, cols); err != nil {
+		return fmt.Errorf("reassigning outbox: %v", err)
+	}
+	if _, err := appdb.Backfills(appdb.BackfillWhere.ConfigID.EQ(configID)).UpdateAll(ctx, exec, cols); err != nil {
+		return fmt.Errorf("reassigning backfills: %v", err)
+	}
+	if _, err := appdb.DeadLetters(appdb.DeadLetterWhere.ConfigID.EQ(configID)).UpdateAll(ctx, exec, cols); err != nil {
+		return fmt.Errorf("reassigning dead letters: %v", err)
+	}
+	if _, err := appdb.MappingRules(appdb.MappingRuleWhere.ConfigID.EQ(configID)).UpdateAll(ctx, exec, cols); err != nil {
+		return fmt.Errorf("reassigning mapping rules: %v", err)
 	}
 	return nil
 }
@@ -101,13 +488,25 @@ func dbConfigFromApiConfig(ctx context.Context, apiConfig apiserver.Configuratio
 	dbConfig.APIRootURL = apiConfig.ApiRootUrl
 	dbConfig.ClientID = apiConfig.ClientId
 	dbConfig.ClientSecret = apiConfig.ClientSecret
+	dbConfig.AuthMode = AuthModeDeviceCode
+	if apiConfig.AuthMode != nil {
+		dbConfig.AuthMode = *apiConfig.AuthMode
+	}
 	dbConfig.RefreshToken = null.StringFromPtr(apiConfig.RefreshToken)
 	dbConfig.ID = null.Int64FromPtr(apiConfig.Id).Int64
 	dbConfig.Enable = null.BoolFromPtr(apiConfig.Enable)
-	dbConfig.RefreshInterval = apiConfig.RefreshInterval
+	dbConfig.RefreshInterval = defaultRefreshInterval
+	if apiConfig.RefreshInterval != nil {
+		dbConfig.RefreshInterval = *apiConfig.RefreshInterval
+	}
 	if apiConfig.RequestTimeout != nil {
 		dbConfig.RequestTimeout = *apiConfig.RequestTimeout
 	}
+	if apiConfig.BatchSize != nil {
+		dbConfig.BatchSize = *apiConfig.BatchSize
+	}
+	dbConfig.DeviceReferenceTemplate = optionalString(apiConfig.DeviceReferenceTemplate)
+	dbConfig.RegisterReferenceTemplate = optionalString(apiConfig.RegisterReferenceTemplate)
 	dbConfig.Active = null.BoolFromPtr(apiConfig.Active)
 	env := frontend.GetEnvironment(ctx)
 	if env != nil {
@@ -122,15 +521,22 @@ func apiConfigFromDbConfig(dbConfig *appdb.Configuration) (apiConfig apiserver.C
 	apiConfig.ApiRootUrl = dbConfig.APIRootURL
 	apiConfig.ClientId = dbConfig.ClientID
 	apiConfig.VerificationUri = dbConfig.VerificationURI.Ptr()
+	apiConfig.LoginState = dbConfig.LoginState.Ptr()
 	apiConfig.ClientSecret = maskSecret(dbConfig.ClientSecret)
+	apiConfig.AuthMode = &dbConfig.AuthMode
 	apiConfig.RefreshToken = common.Ptr(maskSecret(dbConfig.RefreshToken.String))
+	apiConfig.RefreshTokenExpire = dbConfig.RefreshTokenExpire.Ptr()
 	apiConfig.Id = &dbConfig.ID
 	apiConfig.Enable = dbConfig.Enable.Ptr()
-	apiConfig.RefreshInterval = dbConfig.RefreshInterval
+	apiConfig.RefreshInterval = &dbConfig.RefreshInterval
 	apiConfig.RequestTimeout = &dbConfig.RequestTimeout
+	apiConfig.BatchSize = &dbConfig.BatchSize
+	apiConfig.DeviceReferenceTemplate = common.Ptr(referenceTemplateOrDefault(dbConfig.DeviceReferenceTemplate, DefaultDeviceReferenceTemplate))
+	apiConfig.RegisterReferenceTemplate = common.Ptr(referenceTemplateOrDefault(dbConfig.RegisterReferenceTemplate, DefaultRegisterReferenceTemplate))
 	apiConfig.Active = dbConfig.Active.Ptr()
 	apiConfig.UserId = dbConfig.UserID.Ptr()
 	apiConfig.ProjectId = dbConfig.ProjectID.Ptr()
+	apiConfig.Version = &dbConfig.Version
 	return apiConfig, nil
 }
 
@@ -152,6 +558,34 @@ func IsAccessTokenIsValid(dbConfig *appdb.Configuration) bool {
 	return dbConfig.AccessToken.Valid && len(dbConfig.AccessToken.String) > 0 && dbConfig.AccessTokenExpire.Time.After(time.Now())
 }
 
+// IsAccessTokenRefreshNeeded returns true if the access token expires before the next sync run is finished.
+// The token is refreshed ahead of its expiry, so no request is sent with an expired token.
+func IsAccessTokenRefreshNeeded(dbConfig *appdb.Configuration) bool {
+	margin := accessTokenRefreshMargin + time.Duration(dbConfig.RefreshInterval+dbConfig.RequestTimeout)*time.Second
+	return !IsAccessTokenIsValid(dbConfig) || dbConfig.AccessTokenExpire.Time.Before(time.Now().Add(margin))
+}
+
+// IsRefreshTokenExpiring returns true if the refresh token expires soon and the user wasn't notified yet.
+// An already expired refresh token is reported by IsRefreshTokenExpired instead.
+func IsRefreshTokenExpiring(dbConfig *appdb.Configuration) bool {
+	now := time.Now()
+	return dbConfig.RefreshTokenExpire.Valid && !dbConfig.RefreshExpireNotified &&
+		dbConfig.RefreshTokenExpire.Time.After(now) && dbConfig.RefreshTokenExpire.Time.Before(now.Add(refreshTokenExpireWarning))
+}
+
+// IsRefreshTokenExpired returns true if the refresh token has expired, so a new login is needed.
+func IsRefreshTokenExpired(dbConfig *appdb.Configuration) bool {
+	return dbConfig.AuthMode != AuthModeClientCredentials && dbConfig.RefreshTokenExpire.Valid &&
+		!dbConfig.RefreshTokenExpire.Time.After(time.Now())
+}
+
+// SetRefreshExpireNotified stores that the user was notified about the expiring refresh token.
+func SetRefreshExpireNotified(dbConfig *appdb.Configuration) error {
+	dbConfig.RefreshExpireNotified = true
+	err := updateConfig(context.Background(), boil.GetContextDB(), dbConfig, boil.Whitelist(appdb.ConfigurationColumns.RefreshExpireNotified))
+	return err
+}
+
 func GetConfigs(ctx context.Context) ([]apiserver.Configuration, error) {
 	dbConfigs, err := appdb.Configurations().AllG(ctx)
 	if err != nil {
@@ -168,8 +602,53 @@ func GetConfigs(ctx context.Context) ([]apiserver.Configuration, error) {
 	return apiConfigs, nil
 }
 
+// GetDbConfigs returns all configurations. Configurations whose secrets can't be decrypted are skipped, so
+// the other configurations keep working. Each of them is logged once and reported by LoadDbConfigs.
 func GetDbConfigs(ctx context.Context) ([]*appdb.Configuration, error) {
-	return appdb.Configurations().AllG(ctx)
+	dbConfigs, secretErrs, err := LoadDbConfigs(ctx)
+	for _, secretErr := range secretErrs {
+		if _, logged := loggedSecretErrors.LoadOrStore(secretErr.ConfigId, true); !logged {
+			log.Error("conf", "Skipping configuration: %v", secretErr)
+		}
+	}
+	return dbConfigs, err
+}
+
+// loggedSecretErrors holds the IDs of the configurations whose undecryptable secrets were logged.
+var loggedSecretErrors sync.Map
+
+// LoadDbConfigs returns all configurations whose secrets can be decrypted and the errors of the others.
+func LoadDbConfigs(ctx context.Context) ([]*appdb.Configuration, []*SecretError, error) {
+	dbConfigs, err := appdb.Configurations().AllG(ctx)
+	var secretErr *SecretError
+	if !errors.As(err, &secretErr) {
+		return dbConfigs, nil, err
+	}
+
+	// The configurations are read one by one, so only the ones with undecryptable secrets are missing.
+	dbConfigIds, err := appdb.Configurations(
+		qm.Select(appdb.ConfigurationColumns.ID),
+		qm.OrderBy(appdb.ConfigurationColumns.ID),
+	).AllG(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	dbConfigs = nil
+	var secretErrs []*SecretError
+	for _, dbConfigId := range dbConfigIds {
+		dbConfig, err := appdb.FindConfigurationG(ctx, dbConfigId.ID)
+		switch {
+		case errors.As(err, &secretErr):
+			secretErrs = append(secretErrs, secretErr)
+		case errors.Is(err, sql.ErrNoRows):
+			// deleted in the meantime
+		case err != nil:
+			return nil, nil, err
+		default:
+			dbConfigs = append(dbConfigs, dbConfig)
+		}
+	}
+	return dbConfigs, secretErrs, nil
 }
 
 func SetDbConfigActiveState(ctx context.Context, configId int64, state bool) (int64, error) {
@@ -188,27 +667,145 @@ func IsDbConfigEnabled(config *appdb.Configuration) bool {
 	return config.Enable.Valid && config.Enable.Bool
 }
 
+func IsAuthModeValid(authMode string) bool {
+	return authMode == AuthModeDeviceCode || authMode == AuthModeClientCredentials || authMode == AuthModeRefreshToken
+}
+
+// IsLoginNeeded returns true if there is no refresh token to get new access tokens. With client credentials
+// the access token is requested directly, so no login is needed.
 func IsLoginNeeded(config *appdb.Configuration) bool {
+	if config.AuthMode == AuthModeClientCredentials {
+		return false
+	}
 	return !config.RefreshToken.Valid || len(config.RefreshToken.String) == 0
 }
 
+// IsInteractiveLogin returns true if the user has to verify the login.
+func IsInteractiveLogin(config *appdb.Configuration) bool {
+	return config.AuthMode == AuthModeDeviceCode
+}
+
+// UpdateVerification stores the started device authorization. Only the login columns are written, so
+// changes of the configuration made since dbConfig was read are kept.
 func UpdateVerification(dbConfig *appdb.Configuration, verification *model.Verification) error {
 	dbConfig.DeviceCode = null.StringFrom(verification.DeviceCode)
 	dbConfig.VerificationURI = null.StringFrom(verification.VerificationUriComplete)
 	dbConfig.VerificationURIExpire = null.TimeFrom(time.Now().Add(time.Second * time.Duration(verification.ExpiresIn)))
-	dbConfig.VerificationInterval = null.Int32From(verification.Interval)
-	_, err := dbConfig.UpdateG(context.Background(), boil.Infer())
+	dbConfig.VerificationInterval = null.Int32From(max(verification.Interval, minPollInterval))
+	dbConfig.LoginState = null.StringFrom(LoginStatePending)
+	dbConfig.NextPollTS = null.TimeFrom(time.Now().Add(time.Second * time.Duration(dbConfig.VerificationInterval.Int32)))
+	err := updateConfig(context.Background(), boil.GetContextDB(), dbConfig, boil.Whitelist(
+		appdb.ConfigurationColumns.DeviceCode,
+		appdb.ConfigurationColumns.VerificationURI,
+		appdb.ConfigurationColumns.VerificationURIExpire,
+		appdb.ConfigurationColumns.VerificationInterval,
+		appdb.ConfigurationColumns.LoginState,
+		appdb.ConfigurationColumns.NextPollTS,
+	))
 	if err != nil {
 		return fmt.Errorf("error updating validation information in config %d: %w", dbConfig.ID, err)
 	}
 	return nil
 }
 
+// ResetLogin removes the tokens and the verification of the configuration, so the login process
+// starts again with a new device authorization.
+func ResetLogin(dbConfig *appdb.Configuration) error {
+	dbConfig.AccessToken = null.String{}
+	dbConfig.AccessTokenExpire = null.Time{}
+	dbConfig.RefreshToken = null.String{}
+	dbConfig.RefreshTokenExpire = null.Time{}
+	dbConfig.RefreshExpireNotified = false
+	dbConfig.DeviceCode = null.String{}
+	dbConfig.VerificationURI = null.String{}
+	dbConfig.VerificationURIExpire = null.Time{}
+	dbConfig.LoginState = null.String{}
+	dbConfig.NextPollTS = null.Time{}
+	err := updateConfig(context.Background(), boil.GetContextDB(), dbConfig, boil.Whitelist(
+		appdb.ConfigurationColumns.AccessToken,
+		appdb.ConfigurationColumns.AccessTokenExpire,
+		appdb.ConfigurationColumns.RefreshToken,
+		appdb.ConfigurationColumns.RefreshTokenExpire,
+		appdb.ConfigurationColumns.RefreshExpireNotified,
+		appdb.ConfigurationColumns.DeviceCode,
+		appdb.ConfigurationColumns.VerificationURI,
+		appdb.ConfigurationColumns.VerificationURIExpire,
+		appdb.ConfigurationColumns.LoginState,
+		appdb.ConfigurationColumns.NextPollTS,
+	))
+	if err != nil {
+		return fmt.Errorf("error resetting login information in config %d: %w", dbConfig.ID, err)
+	}
+	return nil
+}
+
+// IsPollDue returns true if the token endpoint can be polled for the pending device authorization.
+func IsPollDue(dbConfig *appdb.Configuration) bool {
+	return !dbConfig.NextPollTS.Valid || !time.Now().Before(dbConfig.NextPollTS.Time)
+}
+
+// UpdateNextPoll schedules the next poll of the token endpoint. On slowDown the polling interval is
+// increased permanently for this device authorization.
+func UpdateNextPoll(dbConfig *appdb.Configuration, slowDown bool) error {
+	if slowDown {
+		dbConfig.VerificationInterval = null.Int32From(max(dbConfig.VerificationInterval.Int32, minPollInterval) + slowDownIncrement)
+	}
+	dbConfig.NextPollTS = null.TimeFrom(time.Now().Add(time.Second * time.Duration(max(dbConfig.VerificationInterval.Int32, minPollInterval))))
+	err := updateConfig(context.Background(), boil.GetContextDB(), dbConfig, boil.Whitelist(
+		appdb.ConfigurationColumns.VerificationInterval,
+		appdb.ConfigurationColumns.NextPollTS,
+	))
+	if err != nil {
+		return fmt.Errorf("error updating next poll in config %d: %w", dbConfig.ID, err)
+	}
+	return nil
+}
+
+// SetLoginDenied stops the login process, because the user denied the access.
+func SetLoginDenied(dbConfig *appdb.Configuration) error {
+	dbConfig.LoginState = null.StringFrom(LoginStateDenied)
+	dbConfig.DeviceCode = null.String{}
+	dbConfig.NextPollTS = null.Time{}
+	err := updateConfig(context.Background(), boil.GetContextDB(), dbConfig, boil.Whitelist(
+		appdb.ConfigurationColumns.LoginState,
+		appdb.ConfigurationColumns.DeviceCode,
+		appdb.ConfigurationColumns.NextPollTS,
+	))
+	if err != nil {
+		return fmt.Errorf("error updating login state in config %d: %w", dbConfig.ID, err)
+	}
+	return nil
+}
+
+// UpdateToken stores the tokens received from Zevvy. Only the token and login columns are written, so
+// changes of the configuration made since dbConfig was read are kept.
 func UpdateToken(dbConfig *appdb.Configuration, token *model.Token) error {
 	dbConfig.AccessToken = null.StringFrom(token.AccessToken)
 	dbConfig.AccessTokenExpire = null.TimeFrom(time.Now().Add(time.Second * time.Duration(token.ExpiresIn)))
-	dbConfig.RefreshToken = null.StringFrom(token.RefreshToken)
-	_, err := dbConfig.UpdateG(context.Background(), boil.Infer())
+	dbConfig.LoginState = null.StringFrom(LoginStateAuthorized)
+	dbConfig.DeviceCode = null.String{}
+	dbConfig.NextPollTS = null.Time{}
+	// Client credentials and some refresh grants return no refresh token. The current one is kept then.
+	if token.RefreshToken != "" {
+		dbConfig.RefreshToken = null.StringFrom(token.RefreshToken)
+		// A refresh token without expiry (offline token) is valid until it is revoked.
+		dbConfig.RefreshTokenExpire = null.TimeFrom(time.Now().Add(time.Second * time.Duration(token.RefreshExpiresIn)))
+		dbConfig.RefreshTokenExpire.Valid = token.RefreshExpiresIn > 0
+		// Notify the user again, when the new refresh token is about to expire.
+		if !dbConfig.RefreshTokenExpire.Valid || dbConfig.RefreshTokenExpire.Time.After(time.Now().Add(refreshTokenExpireWarning)) {
+			dbConfig.RefreshExpireNotified = false
+		}
+	}
+	err := updateConfig(context.Background(), boil.GetContextDB(), dbConfig, boil.Whitelist(
+		appdb.ConfigurationColumns.AccessToken,
+		appdb.ConfigurationColumns.AccessTokenExpire,
+		appdb.ConfigurationColumns.LoginState,
+		appdb.ConfigurationColumns.DeviceCode,
+		appdb.ConfigurationColumns.NextPollTS,
+		appdb.ConfigurationColumns.RefreshToken,
+		appdb.ConfigurationColumns.RefreshTokenExpire,
+		appdb.ConfigurationColumns.RefreshExpireNotified,
+	))
 	if err != nil {
 		return fmt.Errorf("error updating token information in config %d: %w", dbConfig.ID, err)
 	}
@@ -0,0 +1,123 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"slices"
+	"testing"
+	"time"
+	"zevvy/appdb"
+	"zevvy/model"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/volatiletech/null/v8"
+)
+
+// The app loop updates the tokens of the configuration it read before. A PUT or PATCH stored in between
+// increases the version and may change any other column, so the token update must only write its own
+// columns.
+func TestLoginUpdatesKeepConcurrentConfigChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(dbConfig *appdb.Configuration) error
+		columns []string
+	}{
+		{
+			name: "token",
+			update: func(dbConfig *appdb.Configuration) error {
+				return UpdateToken(dbConfig, &model.Token{AccessToken: "access", ExpiresIn: 300, RefreshToken: "refresh", RefreshExpiresIn: 1800})
+			},
+			columns: []string{
+				appdb.ConfigurationColumns.AccessToken,
+				appdb.ConfigurationColumns.AccessTokenExpire,
+				appdb.ConfigurationColumns.DeviceCode,
+				appdb.ConfigurationColumns.LoginState,
+				appdb.ConfigurationColumns.NextPollTS,
+				appdb.ConfigurationColumns.RefreshExpireNotified,
+				appdb.ConfigurationColumns.RefreshToken,
+				appdb.ConfigurationColumns.RefreshTokenExpire,
+			},
+		},
+		{
+			name: "verification",
+			update: func(dbConfig *appdb.Configuration) error {
+				return UpdateVerification(dbConfig, &model.Verification{DeviceCode: "device", VerificationUriComplete: "https://login", ExpiresIn: 600, Interval: 5})
+			},
+			columns: []string{
+				appdb.ConfigurationColumns.DeviceCode,
+				appdb.ConfigurationColumns.LoginState,
+				appdb.ConfigurationColumns.NextPollTS,
+				appdb.ConfigurationColumns.VerificationInterval,
+				appdb.ConfigurationColumns.VerificationURI,
+				appdb.ConfigurationColumns.VerificationURIExpire,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, recorder := mockDB(t)
+			// Snapshot read by the app loop before the configuration was updated to version 2.
+			snapshot := &appdb.Configuration{ID: 1, ClientID: "old-client", ClientSecret: "old-secret", Enable: null.BoolFrom(true), Version: 1}
+			mock.ExpectExec("update").WillReturnResult(sqlmock.NewResult(0, 1))
+
+			if err := tt.update(snapshot); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+
+			statements := recorder.all()
+			if len(statements) != 1 {
+				t.Fatalf("got %d statements, want 1", len(statements))
+			}
+			columns := updatedColumns(t, statements[0])
+			want := slices.Sorted(slices.Values(tt.columns))
+			if !slices.Equal(columns, want) {
+				t.Errorf("updated columns %v, want %v", columns, want)
+			}
+			for _, column := range []string{appdb.ConfigurationColumns.Version, appdb.ConfigurationColumns.ClientID, appdb.ConfigurationColumns.ClientSecret, appdb.ConfigurationColumns.Enable} {
+				if slices.Contains(columns, column) {
+					t.Errorf("column %s of the concurrent update is overwritten", column)
+				}
+			}
+		})
+	}
+}
+
+func TestRefreshTokenExpiry(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   appdb.Configuration
+		expiring bool
+		expired  bool
+	}{
+		{name: "no expiry", config: appdb.Configuration{}},
+		{name: "valid", config: appdb.Configuration{RefreshTokenExpire: null.TimeFrom(time.Now().Add(30 * 24 * time.Hour))}},
+		{name: "expiring", config: appdb.Configuration{RefreshTokenExpire: null.TimeFrom(time.Now().Add(time.Hour))}, expiring: true},
+		{name: "expiring notified", config: appdb.Configuration{RefreshTokenExpire: null.TimeFrom(time.Now().Add(time.Hour)), RefreshExpireNotified: true}},
+		{name: "expired", config: appdb.Configuration{RefreshTokenExpire: null.TimeFrom(time.Now().Add(-time.Hour))}, expired: true},
+		{name: "expired notified", config: appdb.Configuration{RefreshTokenExpire: null.TimeFrom(time.Now().Add(-time.Hour)), RefreshExpireNotified: true}, expired: true},
+		{name: "client credentials", config: appdb.Configuration{AuthMode: AuthModeClientCredentials, RefreshTokenExpire: null.TimeFrom(time.Now().Add(-time.Hour))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRefreshTokenExpiring(&tt.config); got != tt.expiring {
+				t.Errorf("IsRefreshTokenExpiring = %v, want %v", got, tt.expiring)
+			}
+			if got := IsRefreshTokenExpired(&tt.config); got != tt.expired {
+				t.Errorf("IsRefreshTokenExpired = %v, want %v", got, tt.expired)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// statementRecorder records the SQL statements executed on the mocked database.
+type statementRecorder struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+func (r *statementRecorder) Match(_ string, actualSQL string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = append(r.statements, actualSQL)
+	return nil
+}
+
+func (r *statementRecorder) all() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.statements)
+}
+
+// mockDB replaces the database used by sqlboiler by a mock for the duration of the test. All statements
+// are accepted and recorded.
+func mockDB(t *testing.T) (sqlmock.Sqlmock, *statementRecorder) {
+	t.Helper()
+	recorder := &statementRecorder{}
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(recorder))
+	if err != nil {
+		t.Fatalf("creating database mock: %v", err)
+	}
+	previous := boil.GetDB()
+	boil.SetDB(db)
+	t.Cleanup(func() {
+		boil.SetDB(previous)
+		_ = db.Close()
+	})
+	return mock, recorder
+}
+
+var setColumnsPattern = regexp.MustCompile(`SET (.*) WHERE`)
+
+// updatedColumns returns the columns set by an UPDATE statement.
+func updatedColumns(t *testing.T, statement string) []string {
+	t.Helper()
+	match := setColumnsPattern.FindStringSubmatch(statement)
+	if match == nil {
+		t.Fatalf("no UPDATE statement: %s", statement)
+	}
+	var columns []string
+	for _, assignment := range strings.Split(match[1], ",") {
+		column, _, _ := strings.Cut(strings.TrimSpace(assignment), "=")
+		columns = append(columns, strings.Trim(column, `"`))
+	}
+	slices.Sort(columns)
+	return columns
+}
@@ -0,0 +1,191 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"zevvy/apiserver"
+	"zevvy/appdb"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func GetDeadLetters(ctx context.Context, configId int32, assetId int32, subtype string, attributeName string) ([]*apiserver.DeadLetter, error) {
+	mods := append(selectDeadLettersMods(configId, assetId, subtype, attributeName), qm.OrderBy(appdb.DeadLetterColumns.ID))
+	dbDeadLetters, err := appdb.DeadLetters(mods...).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var apiDeadLetters []*apiserver.DeadLetter
+	for _, dbDeadLetter := range dbDeadLetters {
+		apiDeadLetters = append(apiDeadLetters, apiDeadLetterFromDbDeadLetter(dbDeadLetter))
+	}
+	return apiDeadLetters, nil
+}
+
+func GetDeadLetter(ctx context.Context, deadLetterId int64) (*apiserver.DeadLetter, error) {
+	dbDeadLetter, err := appdb.FindDeadLetterG(ctx, deadLetterId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching dead letter from database: %v", err)
+	}
+	return apiDeadLetterFromDbDeadLetter(dbDeadLetter), nil
+}
+
+func DeleteDeadLetters(ctx context.Context, configId int32, assetId int32, subtype string, attributeName string) error {
+	mods := selectDeadLettersMods(configId, assetId, subtype, attributeName)
+	_, err := appdb.DeadLetters(mods...).DeleteAllG(ctx)
+	return err
+}
+
+func DeleteDeadLetter(ctx context.Context, deadLetterId int64) error {
+	count, err := appdb.DeadLetters(
+		appdb.DeadLetterWhere.ID.EQ(deadLetterId),
+	).DeleteAllG(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting dead letter from database: %v", err)
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ReplayDeadLetters moves the matching dead letters back to the outbox, so they are sent to Zevvy
+// again. Dead letters of asset attributes no longer configured are kept. Returns the number of
+// replayed dead letters.
+func ReplayDeadLetters(ctx context.Context, configId int32, assetId int32, subtype string, attributeName string) (int, error) {
+	mods := selectDeadLettersMods(configId, assetId, subtype, attributeName)
+	dbDeadLetters, err := appdb.DeadLetters(mods...).AllG(ctx)
+	if err != nil {
+		return 0, err
+	}
+	replayed := 0
+	for _, dbDeadLetter := range dbDeadLetters {
+		err := replayDeadLetter(ctx, dbDeadLetter)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+// ReplayDeadLetter moves the dead letter back to the outbox, so it is sent to Zevvy again.
+func ReplayDeadLetter(ctx context.Context, deadLetterId int64) error {
+	dbDeadLetter, err := appdb.FindDeadLetterG(ctx, deadLetterId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("fetching dead letter from database: %v", err)
+	}
+	return replayDeadLetter(ctx, dbDeadLetter)
+}
+
+func replayDeadLetter(ctx context.Context, dbDeadLetter *appdb.DeadLetter) error {
+	exists, err := appdb.AssetAttributeExistsG(ctx, dbDeadLetter.ConfigID, dbDeadLetter.AssetID, dbDeadLetter.Subtype, dbDeadLetter.AttributeName)
+	if err != nil {
+		return fmt.Errorf("checking asset attribute: %v", err)
+	}
+	if !exists {
+		return ErrNotFound
+	}
+
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	dbOutbox := &appdb.Outbox{
+		ConfigID:      dbDeadLetter.ConfigID,
+		AssetID:       dbDeadLetter.AssetID,
+		Subtype:       dbDeadLetter.Subtype,
+		AttributeName: dbDeadLetter.AttributeName,
+		ReadAt:        dbDeadLetter.ReadAt,
+		Value:         dbDeadLetter.Value,
+	}
+	err = dbOutbox.Upsert(ctx, tx, false,
+		[]string{
+			appdb.OutboxColumns.ConfigID,
+			appdb.OutboxColumns.AssetID,
+			appdb.OutboxColumns.Subtype,
+			appdb.OutboxColumns.AttributeName,
+			appdb.OutboxColumns.ReadAt,
+		},
+		boil.None(),
+		boil.Infer(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting outbox: %v", err)
+	}
+	if _, err := dbDeadLetter.Delete(ctx, tx); err != nil {
+		return fmt.Errorf("deleting dead letter: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+func selectDeadLettersMods(configId int32, assetId int32, subtype string, attributeName string) []qm.QueryMod {
+	var mods []qm.QueryMod
+	if configId > 0 {
+		mods = append(mods, appdb.DeadLetterWhere.ConfigID.EQ(int64(configId)))
+	}
+	if assetId > 0 {
+		mods = append(mods, appdb.DeadLetterWhere.AssetID.EQ(assetId))
+	}
+	if len(subtype) > 0 {
+		mods = append(mods, appdb.DeadLetterWhere.Subtype.EQ(subtype))
+	}
+	if len(attributeName) > 0 {
+		mods = append(mods, appdb.DeadLetterWhere.AttributeName.EQ(attributeName))
+	}
+	return mods
+}
+
+func apiDeadLetterFromDbDeadLetter(dbDeadLetter *appdb.DeadLetter) *apiserver.DeadLetter {
+	var apiDeadLetter *apiserver.DeadLetter
+	if dbDeadLetter != nil {
+		apiDeadLetter = new(apiserver.DeadLetter)
+		apiDeadLetter.Id = common.Ptr(dbDeadLetter.ID)
+		apiDeadLetter.ConfigId = int32(dbDeadLetter.ConfigID)
+		apiDeadLetter.AssetId = dbDeadLetter.AssetID
+		apiDeadLetter.Subtype = dbDeadLetter.Subtype
+		apiDeadLetter.AttributeName = dbDeadLetter.AttributeName
+		apiDeadLetter.DeviceReference = dbDeadLetter.DeviceReference
+		apiDeadLetter.RegisterReference = dbDeadLetter.RegisterReference
+		apiDeadLetter.ReadAt = common.Ptr(dbDeadLetter.ReadAt)
+		apiDeadLetter.Value = dbDeadLetter.Value
+		apiDeadLetter.StatusCode = dbDeadLetter.StatusCode.Ptr()
+		apiDeadLetter.Error = dbDeadLetter.Error
+		apiDeadLetter.ResponseBody = dbDeadLetter.ResponseBody.Ptr()
+		apiDeadLetter.CreatedAt = common.Ptr(dbDeadLetter.CreatedAt)
+	}
+	return apiDeadLetter
+}
@@ -0,0 +1,262 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"zevvy/appdb"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// encryptedPrefix marks values encrypted with AES-256-GCM. Values without the prefix were stored
+// before the encryption was enabled and are read as plain text.
+const encryptedPrefix = "enc:v1:"
+
+// plainPrefix marks values stored unencrypted that start with one of the prefixes themselves, so a secret
+// looking like an encrypted value is read back unchanged.
+const plainPrefix = "plain:v1:"
+
+var (
+	// encryptionKey encrypts the secrets written to the database. Without key the secrets are stored as plain text.
+	encryptionKey cipher.AEAD
+	// decryptionKeys are tried in turn to decrypt the secrets read from the database.
+	decryptionKeys []cipher.AEAD
+)
+
+// InitEncryption reads the key from the ENCRYPTION_KEY environment variable and registers the hooks
+// encrypting client secret, access token and refresh token of the configurations. The previous key
+// in PREVIOUS_ENCRYPTION_KEY is only used to decrypt secrets until the key rotation is done.
+func InitEncryption() error {
+	var err error
+	encryptionKey, err = keyFromEnv("ENCRYPTION_KEY")
+	if err != nil {
+		return err
+	}
+	previousKey, err := keyFromEnv("PREVIOUS_ENCRYPTION_KEY")
+	if err != nil {
+		return err
+	}
+	decryptionKeys = nil
+	for _, key := range []cipher.AEAD{encryptionKey, previousKey} {
+		if key != nil {
+			decryptionKeys = append(decryptionKeys, key)
+		}
+	}
+	if encryptionKey == nil {
+		log.Warn("conf", "ENCRYPTION_KEY is not set. Client secrets and tokens are stored unencrypted.")
+	}
+
+	registerEncryptionHooks()
+	return nil
+}
+
+// registerHooks makes sure the hooks are registered once, because registered hooks add up.
+var registerHooks sync.Once
+
+func registerEncryptionHooks() {
+	registerHooks.Do(func() {
+		appdb.AddConfigurationHook(boil.AfterSelectHook, decryptSecrets)
+		appdb.AddConfigurationHook(boil.BeforeInsertHook, encryptSecrets)
+		appdb.AddConfigurationHook(boil.AfterInsertHook, decryptSecrets)
+		appdb.AddConfigurationHook(boil.BeforeUpdateHook, encryptSecrets)
+		appdb.AddConfigurationHook(boil.AfterUpdateHook, decryptSecrets)
+		appdb.AddConfigurationHook(boil.BeforeUpsertHook, encryptSecrets)
+		appdb.AddConfigurationHook(boil.AfterUpsertHook, decryptSecrets)
+	})
+}
+
+// RotateEncryptionKey re-encrypts the secrets of all configurations with the current key. Secrets
+// stored as plain text are encrypted as well. Returns the number of re-encrypted configurations.
+func RotateEncryptionKey(ctx context.Context) (int, error) {
+	// Without current key the secrets would be written back as plain text.
+	if encryptionKey == nil {
+		return 0, errors.New("ENCRYPTION_KEY is not set")
+	}
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Secrets are decrypted with the current or the previous key when selected ...
+	dbConfigs, err := appdb.Configurations(qm.For("update")).All(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("fetching configs: %v", err)
+	}
+	// ... and encrypted with the current key when updated.
+	for _, dbConfig := range dbConfigs {
+		err := updateConfig(ctx, tx, dbConfig, boil.Whitelist(
+			appdb.ConfigurationColumns.ClientSecret,
+			appdb.ConfigurationColumns.AccessToken,
+			appdb.ConfigurationColumns.RefreshToken,
+		))
+		if err != nil {
+			return 0, fmt.Errorf("updating config %d: %v", dbConfig.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %v", err)
+	}
+	return len(dbConfigs), nil
+}
+
+func keyFromEnv(name string) (cipher.AEAD, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %v", name, err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("%s must be a base64 encoded 256 bit key, got %d bits", name, len(key)*8)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher from %s: %v", name, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// updateConfig updates the configuration. The hooks encrypt the secrets in place before the write and
+// decrypt them after it, so the plain text is restored here if the write fails and the hooks after it
+// don't run. Otherwise, the caller would keep using the encrypted secrets.
+func updateConfig(ctx context.Context, exec boil.ContextExecutor, dbConfig *appdb.Configuration, columns boil.Columns) error {
+	return keepSecrets(dbConfig, func() error {
+		_, err := dbConfig.Update(ctx, exec, columns)
+		return err
+	})
+}
+
+// insertConfig inserts the configuration and restores the plain text secrets if the write fails, see updateConfig.
+func insertConfig(ctx context.Context, exec boil.ContextExecutor, dbConfig *appdb.Configuration, columns boil.Columns) error {
+	return keepSecrets(dbConfig, func() error {
+		return dbConfig.Insert(ctx, exec, columns)
+	})
+}
+
+func keepSecrets(dbConfig *appdb.Configuration, write func() error) error {
+	clientSecret, accessToken, refreshToken := dbConfig.ClientSecret, dbConfig.AccessToken, dbConfig.RefreshToken
+	if err := write(); err != nil {
+		dbConfig.ClientSecret, dbConfig.AccessToken, dbConfig.RefreshToken = clientSecret, accessToken, refreshToken
+		return err
+	}
+	return nil
+}
+
+func encryptSecrets(_ context.Context, _ boil.ContextExecutor, dbConfig *appdb.Configuration) (err error) {
+	if dbConfig.ClientSecret, err = encrypt(dbConfig.ClientSecret); err != nil {
+		return fmt.Errorf("encrypting client secret of config %d: %v", dbConfig.ID, err)
+	}
+	if dbConfig.AccessToken.String, err = encrypt(dbConfig.AccessToken.String); err != nil {
+		return fmt.Errorf("encrypting access token of config %d: %v", dbConfig.ID, err)
+	}
+	if dbConfig.RefreshToken.String, err = encrypt(dbConfig.RefreshToken.String); err != nil {
+		return fmt.Errorf("encrypting refresh token of config %d: %v", dbConfig.ID, err)
+	}
+	return nil
+}
+
+// SecretError is returned if the secrets of a configuration can't be decrypted, e.g. because ENCRYPTION_KEY
+// was changed without setting the former key as PREVIOUS_ENCRYPTION_KEY.
+type SecretError struct {
+	ConfigId int64
+	Err      error
+}
+
+func (e *SecretError) Error() string {
+	return fmt.Sprintf("secrets of config %d cannot be decrypted: %v", e.ConfigId, e.Err)
+}
+
+func (e *SecretError) Unwrap() error {
+	return e.Err
+}
+
+func decryptSecrets(_ context.Context, _ boil.ContextExecutor, dbConfig *appdb.Configuration) (err error) {
+	if dbConfig.ClientSecret, err = decrypt(dbConfig.ClientSecret); err != nil {
+		return &SecretError{ConfigId: dbConfig.ID, Err: fmt.Errorf("client secret: %v", err)}
+	}
+	if dbConfig.AccessToken.String, err = decrypt(dbConfig.AccessToken.String); err != nil {
+		return &SecretError{ConfigId: dbConfig.ID, Err: fmt.Errorf("access token: %v", err)}
+	}
+	if dbConfig.RefreshToken.String, err = decrypt(dbConfig.RefreshToken.String); err != nil {
+		return &SecretError{ConfigId: dbConfig.ID, Err: fmt.Errorf("refresh token: %v", err)}
+	}
+	return nil
+}
+
+// encrypt encrypts the value with the current key. Without key, the value is stored as plain text, only
+// values starting with one of the prefixes are marked with plainPrefix.
+func encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return plaintext, nil
+	}
+	if encryptionKey == nil {
+		if strings.HasPrefix(plaintext, encryptedPrefix) || strings.HasPrefix(plaintext, plainPrefix) {
+			return plainPrefix + plaintext, nil
+		}
+		return plaintext, nil
+	}
+	nonce := make([]byte, encryptionKey.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	ciphertext := encryptionKey.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// decrypt returns the plain text of a value written by encrypt. Values without prefix are returned as they are.
+func decrypt(value string) (string, error) {
+	if plaintext, ok := strings.CutPrefix(value, plainPrefix); ok {
+		return plaintext, nil
+	}
+	if !strings.HasPrefix(value, encryptedPrefix) {
+		return value, nil
+	}
+	if len(decryptionKeys) == 0 {
+		return "", errors.New("value is encrypted, but ENCRYPTION_KEY is not set")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", err
+	}
+	for _, key := range decryptionKeys {
+		if len(ciphertext) < key.NonceSize() {
+			break
+		}
+		plaintext, err := key.Open(nil, ciphertext[:key.NonceSize()], ciphertext[key.NonceSize():], nil)
+		if err == nil {
+			return string(plaintext), nil
+		}
+	}
+	return "", errors.New("value cannot be decrypted with the configured keys")
+}
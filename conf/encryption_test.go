@@ -0,0 +1,326 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"zevvy/appdb"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// newKey returns a random AES-256-GCM key.
+func newKey(t *testing.T) cipher.AEAD {
+	t.Helper()
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return aead
+}
+
+// useKeys sets the current and previous key for the duration of the test. Nil keys are not set.
+func useKeys(t *testing.T, current cipher.AEAD, previous cipher.AEAD) {
+	t.Helper()
+	savedEncryptionKey, savedDecryptionKeys := encryptionKey, decryptionKeys
+	t.Cleanup(func() {
+		encryptionKey, decryptionKeys = savedEncryptionKey, savedDecryptionKeys
+	})
+	encryptionKey, decryptionKeys = current, nil
+	for _, key := range []cipher.AEAD{current, previous} {
+		if key != nil {
+			decryptionKeys = append(decryptionKeys, key)
+		}
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	tests := []struct {
+		name      string
+		plaintext string
+	}{
+		{name: "secret", plaintext: "s3cr3t"},
+		{name: "empty", plaintext: ""},
+		{name: "looks encrypted", plaintext: encryptedPrefix + "c2VjcmV0"},
+		{name: "looks marked", plaintext: plainPrefix + "secret"},
+		{name: "unicode", plaintext: "pässwört"},
+	}
+	for _, withKey := range []bool{true, false} {
+		for _, tt := range tests {
+			name := tt.name + " without key"
+			if withKey {
+				name = tt.name + " with key"
+			}
+			t.Run(name, func(t *testing.T) {
+				var key cipher.AEAD
+				if withKey {
+					key = newKey(t)
+				}
+				useKeys(t, key, nil)
+
+				stored, err := encrypt(tt.plaintext)
+				if err != nil {
+					t.Fatalf("encrypt: %v", err)
+				}
+				if withKey && tt.plaintext != "" && (!strings.HasPrefix(stored, encryptedPrefix) || strings.Contains(stored, tt.plaintext)) {
+					t.Errorf("value %q is not encrypted: %q", tt.plaintext, stored)
+				}
+				read, err := decrypt(stored)
+				if err != nil {
+					t.Fatalf("decrypt: %v", err)
+				}
+				if read != tt.plaintext {
+					t.Errorf("read %q, want %q", read, tt.plaintext)
+				}
+			})
+		}
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	current, previous, other := newKey(t), newKey(t), newKey(t)
+	encryptWith := func(key cipher.AEAD, plaintext string) string {
+		useKeys(t, key, nil)
+		stored, err := encrypt(plaintext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return stored
+	}
+	byCurrent := encryptWith(current, "current")
+	byPrevious := encryptWith(previous, "previous")
+	byOther := encryptWith(other, "other")
+
+	tests := []struct {
+		name    string
+		value   string
+		keys    []cipher.AEAD
+		want    string
+		wantErr bool
+	}{
+		{name: "current key", value: byCurrent, keys: []cipher.AEAD{current, previous}, want: "current"},
+		{name: "previous key", value: byPrevious, keys: []cipher.AEAD{current, previous}, want: "previous"},
+		{name: "unknown key", value: byOther, keys: []cipher.AEAD{current, previous}, wantErr: true},
+		{name: "no key", value: byCurrent, wantErr: true},
+		{name: "plain text stored before encryption", value: "legacy", keys: []cipher.AEAD{current}, want: "legacy"},
+		{name: "invalid base64", value: encryptedPrefix + "%%%", keys: []cipher.AEAD{current}, wantErr: true},
+		{name: "too short", value: encryptedPrefix + "AAAA", keys: []cipher.AEAD{current}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var currentKey, previousKey cipher.AEAD
+			if len(tt.keys) > 0 {
+				currentKey = tt.keys[0]
+			}
+			if len(tt.keys) > 1 {
+				previousKey = tt.keys[1]
+			}
+			useKeys(t, currentKey, previousKey)
+			got, err := decrypt(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decrypt error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("decrypt %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecretHooks(t *testing.T) {
+	useKeys(t, newKey(t), nil)
+	dbConfig := &appdb.Configuration{
+		ID:           1,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		AccessToken:  null.StringFrom("access"),
+		RefreshToken: null.StringFrom("refresh"),
+	}
+
+	if err := encryptSecrets(nil, nil, dbConfig); err != nil {
+		t.Fatalf("encrypting: %v", err)
+	}
+	for name, value := range map[string]string{"client secret": dbConfig.ClientSecret, "access token": dbConfig.AccessToken.String, "refresh token": dbConfig.RefreshToken.String} {
+		if !strings.HasPrefix(value, encryptedPrefix) {
+			t.Errorf("%s is not encrypted: %q", name, value)
+		}
+	}
+	if dbConfig.ClientID != "client" {
+		t.Errorf("client ID changed to %q", dbConfig.ClientID)
+	}
+
+	if err := decryptSecrets(nil, nil, dbConfig); err != nil {
+		t.Fatalf("decrypting: %v", err)
+	}
+	if dbConfig.ClientSecret != "secret" || dbConfig.AccessToken.String != "access" || dbConfig.RefreshToken.String != "refresh" {
+		t.Errorf("decrypted secrets %q, %q, %q", dbConfig.ClientSecret, dbConfig.AccessToken.String, dbConfig.RefreshToken.String)
+	}
+}
+
+// recordedValue is a sqlmock argument accepting any value and recording it.
+type recordedValue struct {
+	value *driver.Value
+}
+
+func (r recordedValue) Match(value driver.Value) bool {
+	*r.value = value
+	return true
+}
+
+func TestRotateEncryptionKey(t *testing.T) {
+	registerEncryptionHooks()
+	previous, current := newKey(t), newKey(t)
+	useKeys(t, previous, nil)
+	storedSecret, err := encrypt("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useKeys(t, current, previous)
+	mock, _ := mockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("select").WillReturnRows(sqlmock.NewRows([]string{"id", "client_secret", "access_token", "refresh_token"}).
+		AddRow(int64(1), storedSecret, "plain access token", nil))
+	var clientSecret, accessToken, refreshToken, id driver.Value
+	mock.ExpectExec("update").
+		WithArgs(recordedValue{&clientSecret}, recordedValue{&accessToken}, recordedValue{&refreshToken}, recordedValue{&id}).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	count, err := RotateEncryptionKey(t.Context())
+	if err != nil {
+		t.Fatalf("rotating: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("rotated %d configurations, want 1", count)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The stored values can be read with the current key only.
+	useKeys(t, current, nil)
+	tests := []struct {
+		name  string
+		value driver.Value
+		want  string
+	}{
+		{name: "client secret encrypted with the previous key", value: clientSecret, want: "secret"},
+		{name: "access token stored as plain text", value: accessToken, want: "plain access token"},
+	}
+	for _, tt := range tests {
+		stored, ok := tt.value.(string)
+		if !ok || !strings.HasPrefix(stored, encryptedPrefix) {
+			t.Errorf("%s is stored as %v", tt.name, tt.value)
+			continue
+		}
+		if got, err := decrypt(stored); err != nil || got != tt.want {
+			t.Errorf("%s decrypts to %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+	if refreshToken != nil {
+		t.Errorf("missing refresh token is stored as %v", refreshToken)
+	}
+}
+
+func TestRotateEncryptionKeyWithoutKey(t *testing.T) {
+	useKeys(t, nil, newKey(t))
+	mock, recorder := mockDB(t)
+
+	if _, err := RotateEncryptionKey(t.Context()); err == nil {
+		t.Fatal("rotating without ENCRYPTION_KEY succeeded")
+	}
+	if statements := recorder.all(); len(statements) > 0 {
+		t.Errorf("executed %v", statements)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFailedUpdateKeepsSecrets(t *testing.T) {
+	registerEncryptionHooks()
+	useKeys(t, newKey(t), nil)
+	mock, _ := mockDB(t)
+	mock.ExpectExec("update").WillReturnError(errors.New("connection reset"))
+	dbConfig := &appdb.Configuration{
+		ID:           1,
+		ClientSecret: "secret",
+		AccessToken:  null.StringFrom("access"),
+		RefreshToken: null.StringFrom("refresh"),
+	}
+
+	if err := updateConfig(t.Context(), boil.GetContextDB(), dbConfig, boil.Infer()); err == nil {
+		t.Fatal("update succeeded")
+	}
+	if dbConfig.ClientSecret != "secret" || dbConfig.AccessToken.String != "access" || dbConfig.RefreshToken.String != "refresh" {
+		t.Errorf("secrets after failed update %q, %q, %q", dbConfig.ClientSecret, dbConfig.AccessToken.String, dbConfig.RefreshToken.String)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadDbConfigsSkipsUndecryptableSecrets(t *testing.T) {
+	registerEncryptionHooks()
+	useKeys(t, newKey(t), nil)
+	unknownSecret, err := encrypt("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useKeys(t, newKey(t), nil)
+	knownSecret, err := encrypt("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock, _ := mockDB(t)
+	columns := []string{"id", "client_secret"}
+	mock.ExpectQuery("select").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(int64(1), knownSecret).
+		AddRow(int64(2), unknownSecret))
+	mock.ExpectQuery("select").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(2)))
+	mock.ExpectQuery("select").WillReturnRows(sqlmock.NewRows(columns).AddRow(int64(1), knownSecret))
+	mock.ExpectQuery("select").WillReturnRows(sqlmock.NewRows(columns).AddRow(int64(2), unknownSecret))
+
+	dbConfigs, secretErrs, err := LoadDbConfigs(t.Context())
+	if err != nil {
+		t.Fatalf("loading: %v", err)
+	}
+	if len(dbConfigs) != 1 || dbConfigs[0].ID != 1 || dbConfigs[0].ClientSecret != "secret" {
+		t.Errorf("loaded %+v, want config 1 with decrypted secret", dbConfigs)
+	}
+	if len(secretErrs) != 1 || secretErrs[0].ConfigId != 2 {
+		t.Errorf("secret errors %v, want config 2", secretErrs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -18,36 +18,123 @@ create schema if not exists zevvy;
 -- Should be editable by eliona frontend.
 create table if not exists zevvy.configuration
 (
-    id                      bigserial primary key,
-    auth_root_url           text    not null,
-    api_root_url            text    not null,
-    client_id               text    not null,
-    client_secret           text    not null,
-    device_code             text,
-    verification_uri        text,
-    verification_uri_expire timestamp with time zone,
-    verification_interval   integer,
-    access_token            text,
-    access_token_expire     timestamp with time zone,
-    refresh_token           text,
-    refresh_interval        integer not null default 60,
-    request_timeout         integer not null default 120,
-    active                  boolean          default false,
-    enable                  boolean          default false,
-    user_id                 text,
-    project_id              text
+    id                          bigserial primary key,
+    auth_root_url               text    not null,
+    api_root_url                text    not null,
+    client_id                   text    not null,
+    client_secret               text    not null,
+    auth_mode                   text    not null default 'device_code',
+    device_code                 text,
+    verification_uri            text,
+    verification_uri_expire     timestamp with time zone,
+    verification_interval       integer,
+    login_state                 text,
+    next_poll_ts                timestamp with time zone,
+    access_token                text,
+    access_token_expire         timestamp with time zone,
+    refresh_token               text,
+    refresh_token_expire        timestamp with time zone,
+    refresh_expire_notified     boolean not null default false,
+    refresh_interval            integer not null default 60,
+    request_timeout             integer not null default 120,
+    batch_size                  integer not null default 1000,
+    active                      boolean          default false,
+    enable                      boolean          default false,
+    user_id                     text,
+    project_id                  text,
+    version                     integer not null default 1,
+    device_reference_template   text,
+    register_reference_template text
+);
+
+create table if not exists zevvy.mapping_rule
+(
+    id                  bigserial primary key,
+    config_id           bigint                   not null references zevvy.configuration (id) on delete restrict,
+    asset_type          text,
+    parent_asset_id     integer,
+    include_descendants boolean                  not null default false,
+    project_id          text,
+    tag_expression      text,
+    subtype             text                     not null,
+    attribute_name      text                     not null,
+    precision           integer,
+    enable              boolean                  not null default true,
+    last_reconcile_ts   timestamp with time zone,
+    last_error          text,
+    created_at          timestamp with time zone not null default current_timestamp
 );
 
 create table if not exists zevvy.asset_attribute
 (
-    config_id          integer                  not null,
+    config_id                   bigint                   not null references zevvy.configuration (id) on delete restrict,
+    asset_id                    integer                  not null,
+    subtype                     text                     not null,
+    attribute_name              text                     not null,
+    device_reference            text                     not null,
+    register_reference          text                     not null,
+    latest_ts                   timestamp with time zone not null default current_timestamp,
+    precision                   integer,
+    last_attempt_ts             timestamp with time zone,
+    last_success_ts             timestamp with time zone,
+    last_error                  text,
+    consecutive_failures        integer                  not null default 0,
+    total_sent                  bigint                   not null default 0,
+    rule_id                     bigint references zevvy.mapping_rule (id) on delete set null,
+    device_reference_rendered   boolean,
+    register_reference_rendered boolean,
+    retired_ts                  timestamp with time zone,
+    primary key (config_id, asset_id, subtype, attribute_name),
+    constraint asset_attribute_reference_key unique (config_id, device_reference, register_reference)
+        deferrable initially deferred
+);
+
+create table if not exists zevvy.backfill
+(
+    id             bigserial primary key,
+    config_id      bigint                   not null,
+    asset_id       integer                  not null,
+    subtype        text                     not null,
+    attribute_name text                     not null,
+    start_ts       timestamp with time zone not null,
+    end_ts         timestamp with time zone not null,
+    progress_ts    timestamp with time zone not null,
+    status         text                     not null default 'pending',
+    error          text,
+    created_at     timestamp with time zone not null default current_timestamp,
+    updated_at     timestamp with time zone not null default current_timestamp
+);
+
+create table if not exists zevvy.outbox
+(
+    id              bigserial primary key,
+    config_id       bigint                   not null,
+    asset_id        integer                  not null,
+    subtype         text                     not null,
+    attribute_name  text                     not null,
+    read_at         timestamp with time zone not null,
+    value           double precision         not null,
+    last_attempt_ts timestamp with time zone,
+    last_error      text,
+    created_at      timestamp with time zone not null default current_timestamp,
+    unique (config_id, asset_id, subtype, attribute_name, read_at)
+);
+
+create table if not exists zevvy.dead_letter
+(
+    id                 bigserial primary key,
+    config_id          bigint                   not null,
     asset_id           integer                  not null,
     subtype            text                     not null,
     attribute_name     text                     not null,
     device_reference   text                     not null,
     register_reference text                     not null,
-    latest_ts          timestamp with time zone not null default current_timestamp,
-    primary key (config_id, asset_id, subtype, attribute_name)
+    read_at            timestamp with time zone not null,
+    value              double precision         not null,
+    status_code        integer,
+    error              text                     not null,
+    response_body      text,
+    created_at         timestamp with time zone not null default current_timestamp
 );
 
 -- Makes the new objects available for all other init steps
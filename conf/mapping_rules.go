@@ -0,0 +1,570 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"zevvy/apiserver"
+	"zevvy/appdb"
+	"zevvy/eliona"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// CreateMappingRule stores a new mapping rule and reconciles it at once, so the asset attributes of the
+// rule exist when the rule is returned. Errors of the reconciliation are reported in lastError.
+func CreateMappingRule(ctx context.Context, apiMappingRule apiserver.MappingRule) (*apiserver.MappingRule, error) {
+	dbMappingRule := dbMappingRuleFromApiMappingRule(apiMappingRule)
+	if err := validateMappingRule(ctx, dbMappingRule); err != nil {
+		return nil, err
+	}
+	if err := dbMappingRule.InsertG(ctx, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("inserting mapping rule: %v", err)
+	}
+	if dbMappingRule.Enable {
+		_, _ = ReconcileMappingRule(ctx, dbMappingRule)
+	}
+	return apiMappingRuleFromDbMappingRule(ctx, dbMappingRule)
+}
+
+// UpdateMappingRule replaces the mapping rule and reconciles it at once. Asset attributes no longer
+// matching the changed rule are retired.
+func UpdateMappingRule(ctx context.Context, ruleId int64, apiMappingRule apiserver.MappingRule) (*apiserver.MappingRule, error) {
+	dbMappingRule, err := getDbMappingRule(ctx, ruleId)
+	if err != nil {
+		return nil, err
+	}
+	updatedRule := dbMappingRuleFromApiMappingRule(apiMappingRule)
+	updatedRule.ID = dbMappingRule.ID
+	updatedRule.CreatedAt = dbMappingRule.CreatedAt
+	if err := validateMappingRule(ctx, updatedRule); err != nil {
+		return nil, err
+	}
+	_, err = updatedRule.UpdateG(ctx, boil.Blacklist(
+		appdb.MappingRuleColumns.ID,
+		appdb.MappingRuleColumns.LastReconcileTS,
+		appdb.MappingRuleColumns.LastError,
+		appdb.MappingRuleColumns.CreatedAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("updating mapping rule: %v", err)
+	}
+	if updatedRule.Enable {
+		_, _ = ReconcileMappingRule(ctx, updatedRule)
+	}
+	return apiMappingRuleFromDbMappingRule(ctx, updatedRule)
+}
+
+func GetMappingRules(ctx context.Context, configId int32) ([]*apiserver.MappingRule, error) {
+	var mods []qm.QueryMod
+	if configId > 0 {
+		mods = append(mods, appdb.MappingRuleWhere.ConfigID.EQ(int64(configId)))
+	}
+	mods = append(mods, qm.OrderBy(appdb.MappingRuleColumns.ID))
+	dbMappingRules, err := appdb.MappingRules(mods...).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var apiMappingRules []*apiserver.MappingRule
+	for _, dbMappingRule := range dbMappingRules {
+		apiMappingRule, err := apiMappingRuleFromDbMappingRule(ctx, dbMappingRule)
+		if err != nil {
+			return nil, err
+		}
+		apiMappingRules = append(apiMappingRules, apiMappingRule)
+	}
+	return apiMappingRules, nil
+}
+
+func GetMappingRule(ctx context.Context, ruleId int64) (*apiserver.MappingRule, error) {
+	dbMappingRule, err := getDbMappingRule(ctx, ruleId)
+	if err != nil {
+		return nil, err
+	}
+	return apiMappingRuleFromDbMappingRule(ctx, dbMappingRule)
+}
+
+// GetDbEnabledMappingRules returns all enabled mapping rules.
+func GetDbEnabledMappingRules(ctx context.Context) ([]*appdb.MappingRule, error) {
+	return appdb.MappingRules(
+		appdb.MappingRuleWhere.Enable.EQ(true),
+		qm.OrderBy(appdb.MappingRuleColumns.ID),
+	).AllG(ctx)
+}
+
+// DeleteMappingRule deletes the mapping rule and all asset attributes created by it together with their
+// queued measurements, dead letters and backfills.
+func DeleteMappingRule(ctx context.Context, ruleId int64) error {
+	dbMappingRule, err := getDbMappingRule(ctx, ruleId)
+	if err != nil {
+		return err
+	}
+	dbAssetAttributes, err := appdb.AssetAttributes(
+		appdb.AssetAttributeWhere.RuleID.EQ(null.Int64From(dbMappingRule.ID)),
+	).AllG(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching asset attributes of mapping rule: %v", err)
+	}
+	for _, dbAssetAttribute := range dbAssetAttributes {
+		if err := purgeAssetAttribute(ctx, dbAssetAttribute); err != nil {
+			return err
+		}
+	}
+	if _, err := dbMappingRule.DeleteG(ctx); err != nil {
+		return fmt.Errorf("deleting mapping rule: %v", err)
+	}
+	return nil
+}
+
+// ReconcileMappingRuleById reconciles the mapping rule with the given ID.
+func ReconcileMappingRuleById(ctx context.Context, ruleId int64) (apiserver.ReconcileResult, error) {
+	dbMappingRule, err := getDbMappingRule(ctx, ruleId)
+	if err != nil {
+		return apiserver.ReconcileResult{}, err
+	}
+	return ReconcileMappingRule(ctx, dbMappingRule)
+}
+
+// ReconcileMappingRule expands the mapping rule to asset attributes: assets newly selected by the rule are
+// mapped and the asset attributes of assets no longer selected are retired. Retired asset attributes keep
+// their queued measurements, dead letters and latest timestamp and are reactivated as soon as their assets
+// are selected again. Only asset attributes of assets deleted in Eliona are deleted. Asset attributes already
+// configured manually or by another rule are left untouched. Assets whose references are already used by
+// another asset attribute of the configuration are skipped. The result of the reconciliation is stored
+// with the rule.
+func ReconcileMappingRule(ctx context.Context, dbMappingRule *appdb.MappingRule) (apiserver.ReconcileResult, error) {
+	result, err := reconcileMappingRule(ctx, dbMappingRule)
+	dbMappingRule.LastReconcileTS = null.TimeFrom(time.Now())
+	dbMappingRule.LastError = null.String{}
+	if err != nil {
+		dbMappingRule.LastError = null.StringFrom(err.Error())
+	} else if len(result.Collisions) > 0 {
+		dbMappingRule.LastError = null.StringFrom(fmt.Sprintf("%d asset attributes skipped, because their references are already used by other asset attributes", len(result.Collisions)))
+	} else if len(result.Skipped) > 0 {
+		dbMappingRule.LastError = null.StringFrom(fmt.Sprintf("%d assets skipped, because their references can't be rendered", len(result.Skipped)))
+	}
+	_, updateErr := dbMappingRule.UpdateG(ctx, boil.Whitelist(
+		appdb.MappingRuleColumns.LastReconcileTS,
+		appdb.MappingRuleColumns.LastError,
+	))
+	if err == nil && updateErr != nil {
+		err = fmt.Errorf("updating mapping rule: %v", updateErr)
+	}
+	return result, err
+}
+
+func reconcileMappingRule(ctx context.Context, dbMappingRule *appdb.MappingRule) (apiserver.ReconcileResult, error) {
+	var result apiserver.ReconcileResult
+
+	// Nothing is retired if the selection fails, as the cause may be a temporary change in Eliona.
+	selected, skipped, err := selectAssetAttributes(ctx, dbMappingRule)
+	if err != nil {
+		return result, err
+	}
+	result.Skipped = skipped
+	skippedAssetIds := make(map[int32]bool)
+	for _, skippedAsset := range skipped {
+		skippedAssetIds[skippedAsset.AssetId] = true
+	}
+
+	dbAssetAttributes, err := appdb.AssetAttributes(
+		appdb.AssetAttributeWhere.RuleID.EQ(null.Int64From(dbMappingRule.ID)),
+	).AllG(ctx)
+	if err != nil {
+		return result, fmt.Errorf("fetching asset attributes of mapping rule: %v", err)
+	}
+	for _, dbAssetAttribute := range dbAssetAttributes {
+		_, matching := selected[dbAssetAttribute.AssetID]
+		if skippedAssetIds[dbAssetAttribute.AssetID] {
+			continue
+		}
+		if matching && dbAssetAttribute.ConfigID == dbMappingRule.ConfigID &&
+			dbAssetAttribute.Subtype == dbMappingRule.Subtype && dbAssetAttribute.AttributeName == dbMappingRule.AttributeName {
+			delete(selected, dbAssetAttribute.AssetID)
+			if dbAssetAttribute.RetiredTS.Valid {
+				if err := setAssetAttributeRetired(ctx, dbAssetAttribute, null.Time{}); err != nil {
+					return result, err
+				}
+				result.Reactivated++
+			}
+			continue
+		}
+
+		// An asset missing in the selection may be changed only for a while, so the asset attribute is
+		// deleted only if the asset is deleted in Eliona.
+		apiAsset, err := eliona.GetAsset(dbAssetAttribute)
+		if err != nil {
+			return result, fmt.Errorf("fetching asset %d: %v", dbAssetAttribute.AssetID, err)
+		}
+		switch {
+		case apiAsset == nil:
+			if err := purgeAssetAttribute(ctx, dbAssetAttribute); err != nil {
+				return result, err
+			}
+			result.Deleted++
+		case !dbAssetAttribute.RetiredTS.Valid:
+			if err := setAssetAttributeRetired(ctx, dbAssetAttribute, null.TimeFrom(time.Now())); err != nil {
+				return result, err
+			}
+			result.Retired++
+		}
+	}
+
+	result.Created, result.Collisions, err = createAssetAttributes(ctx, dbMappingRule.ConfigID, selected)
+	return result, err
+}
+
+// createAssetAttributes stores the selected asset attributes not configured yet in one transaction and
+// returns their number. Asset attributes whose references are already used are returned as collisions.
+func createAssetAttributes(ctx context.Context, configId int64, selected map[int32]*appdb.AssetAttribute) (int32, []apiserver.ReferenceCollision, error) {
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if err := lockReferences(ctx, tx, configId); err != nil {
+		return 0, nil, err
+	}
+
+	var created int32
+	var collisions []apiserver.ReferenceCollision
+	for _, assetId := range sortedAssetIds(selected) {
+		dbAssetAttribute := selected[assetId]
+		exists, err := appdb.AssetAttributeExists(ctx, tx, dbAssetAttribute.ConfigID, assetId, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName)
+		if err != nil {
+			return 0, nil, fmt.Errorf("checking asset attribute: %v", err)
+		}
+		if exists {
+			continue
+		}
+		var collisionErr *ReferenceCollisionError
+		err = checkReferenceCollision(ctx, tx, dbAssetAttribute)
+		if errors.As(err, &collisionErr) {
+			collisions = append(collisions, collisionErr.Collision)
+			continue
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		if err := dbAssetAttribute.Insert(ctx, tx, boil.Infer()); err != nil {
+			return 0, nil, fmt.Errorf("inserting asset attribute: %v", err)
+		}
+		created++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("committing transaction: %v", err)
+	}
+	return created, collisions, nil
+}
+
+// PreviewMappingRule returns the asset attributes the mapping rule would create without storing anything.
+// Asset attributes already configured are listed separately, as the rule leaves them untouched, and so are
+// asset attributes skipped because of colliding references.
+func PreviewMappingRule(ctx context.Context, apiMappingRule apiserver.MappingRule) (apiserver.MappingRulePreview, error) {
+	preview := apiserver.MappingRulePreview{
+		AssetAttributes:   []apiserver.AssetAttribute{},
+		AlreadyConfigured: []apiserver.AssetAttribute{},
+	}
+	dbMappingRule := dbMappingRuleFromApiMappingRule(apiMappingRule)
+	if err := validateMappingRule(ctx, dbMappingRule); err != nil {
+		return preview, err
+	}
+	selected, skipped, err := selectAssetAttributes(ctx, dbMappingRule)
+	if err != nil {
+		return preview, err
+	}
+	preview.Skipped = skipped
+	previewed := make(map[referenceKey]*appdb.AssetAttribute)
+	for _, assetId := range sortedAssetIds(selected) {
+		dbAssetAttribute, err := appdb.FindAssetAttributeG(ctx, dbMappingRule.ConfigID, assetId, dbMappingRule.Subtype, dbMappingRule.AttributeName)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			key := referenceKeyOf(selected[assetId])
+			var collisionErr *ReferenceCollisionError
+			err := checkReferenceCollision(ctx, boil.GetContextDB(), selected[assetId])
+			switch {
+			case errors.As(err, &collisionErr):
+				preview.Collisions = append(preview.Collisions, collisionErr.Collision)
+			case err != nil:
+				return preview, err
+			case previewed[key] != nil:
+				preview.Collisions = append(preview.Collisions, referenceCollision(key, previewed[key], selected[assetId]))
+			default:
+				previewed[key] = selected[assetId]
+				preview.AssetAttributes = append(preview.AssetAttributes, *apiAssetAttributeFromDbAssetAttribute(selected[assetId]))
+			}
+		case err != nil:
+			return preview, fmt.Errorf("fetching asset attribute: %v", err)
+		default:
+			preview.AlreadyConfigured = append(preview.AlreadyConfigured, *apiAssetAttributeFromDbAssetAttribute(dbAssetAttribute))
+		}
+	}
+	return preview, nil
+}
+
+// selectAssetAttributes returns the asset attributes selected by the mapping rule by asset ID. Assets
+// whose asset type has no numeric attribute with the name and subtype of the rule are skipped. If the
+// rule selects an asset type without such an attribute, the rule itself is invalid. Assets whose
+// references can't be rendered are returned as skipped, so one asset doesn't block the others.
+func selectAssetAttributes(ctx context.Context, dbMappingRule *appdb.MappingRule) (map[int32]*appdb.AssetAttribute, []apiserver.SkippedAsset, error) {
+	var matchTags tagExpression
+	if dbMappingRule.TagExpression.Valid {
+		var err error
+		matchTags, err = parseTagExpression(dbMappingRule.TagExpression.String)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tag expression %v", err)
+		}
+	}
+
+	assetTypes := make(map[string]*api.AssetType)
+	if dbMappingRule.AssetType.Valid {
+		assetType, err := eliona.GetAssetType(dbMappingRule.AssetType.String)
+		if err != nil {
+			return nil, nil, err
+		}
+		validationErr := &ValidationError{}
+		validateAttributeSchema(validationErr, "assetType", dbMappingRule.AssetType.String, assetType, dbMappingRule.Subtype, dbMappingRule.AttributeName)
+		if err := validationErr.OrNil(); err != nil {
+			return nil, nil, err
+		}
+		assetTypes[dbMappingRule.AssetType.String] = assetType
+	}
+
+	renderer, err := newReferenceRenderer(ctx, dbMappingRule.ConfigID)
+	if err != nil {
+		return nil, nil, err
+	}
+	apiAssets, err := eliona.GetAssets(dbMappingRule.AssetType.String, dbMappingRule.ProjectID.String)
+	if err != nil {
+		return nil, nil, err
+	}
+	selected := make(map[int32]*appdb.AssetAttribute)
+	var skipped []apiserver.SkippedAsset
+	for _, apiAsset := range apiAssets {
+		if !matchesMappingRule(dbMappingRule, matchTags, apiAsset) {
+			continue
+		}
+		assetType, ok := assetTypes[apiAsset.AssetType]
+		if !ok {
+			assetType, err = eliona.GetAssetType(apiAsset.AssetType)
+			if err != nil {
+				return nil, nil, err
+			}
+			assetTypes[apiAsset.AssetType] = assetType
+		}
+		validationErr := &ValidationError{}
+		validateAttributeSchema(validationErr, "assetType", apiAsset.AssetType, assetType, dbMappingRule.Subtype, dbMappingRule.AttributeName)
+		if len(validationErr.Fields) > 0 {
+			continue
+		}
+		dbAssetAttribute := &appdb.AssetAttribute{
+			ConfigID:      dbMappingRule.ConfigID,
+			AssetID:       apiAsset.GetId(),
+			Subtype:       dbMappingRule.Subtype,
+			AttributeName: dbMappingRule.AttributeName,
+			Precision:     dbMappingRule.Precision,
+			RuleID:        null.NewInt64(dbMappingRule.ID, dbMappingRule.ID != 0),
+		}
+		if err := renderer.setReferences(dbAssetAttribute, &apiAsset); err != nil {
+			skipped = append(skipped, apiserver.SkippedAsset{AssetId: apiAsset.GetId(), Message: err.Error()})
+			continue
+		}
+		selected[apiAsset.GetId()] = dbAssetAttribute
+	}
+	return selected, skipped, nil
+}
+
+// matchesMappingRule checks if the asset is selected by the project, parent asset and tag expression of
+// the rule. With a parent asset, only its direct children in the functional or locational tree are
+// selected, or all assets below it if descendants are included.
+func matchesMappingRule(dbMappingRule *appdb.MappingRule, matchTags tagExpression, apiAsset api.Asset) bool {
+	if dbMappingRule.ProjectID.Valid && apiAsset.ProjectId != dbMappingRule.ProjectID.String {
+		return false
+	}
+	if dbMappingRule.ParentAssetID.Valid {
+		parentAssetId := dbMappingRule.ParentAssetID.Int32
+		isChild := apiAsset.GetParentFunctionalAssetId() == parentAssetId || apiAsset.GetParentLocationalAssetId() == parentAssetId
+		isDescendant := apiAsset.GetId() != parentAssetId &&
+			(slices.Contains(apiAsset.FunctionalAssetIdPath, parentAssetId) || slices.Contains(apiAsset.LocationalAssetIdPath, parentAssetId))
+		if !isChild && !(dbMappingRule.IncludeDescendants && isDescendant) {
+			return false
+		}
+	}
+	if matchTags != nil {
+		tags := make(map[string]bool)
+		for _, tag := range apiAsset.Tags {
+			tags[tag] = true
+		}
+		if !matchTags(tags) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedAssetIds(assetAttributes map[int32]*appdb.AssetAttribute) []int32 {
+	var assetIds []int32
+	for assetId := range assetAttributes {
+		assetIds = append(assetIds, assetId)
+	}
+	slices.Sort(assetIds)
+	return assetIds
+}
+
+// setAssetAttributeRetired retires the asset attribute with the given time or reactivates it without one.
+func setAssetAttributeRetired(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute, retiredTS null.Time) error {
+	dbAssetAttribute.RetiredTS = retiredTS
+	if _, err := dbAssetAttribute.UpdateG(ctx, boil.Whitelist(appdb.AssetAttributeColumns.RetiredTS)); err != nil {
+		return fmt.Errorf("updating asset attribute: %v", err)
+	}
+	return nil
+}
+
+// purgeAssetAttribute deletes an asset attribute created by a mapping rule together with its queued
+// measurements, dead letters and backfills, which can no longer be sent.
+func purgeAssetAttribute(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute) error {
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if err := deleteAssetAttribute(ctx, tx, dbAssetAttribute); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %v", err)
+	}
+	return nil
+}
+
+// validateMappingRule checks that the rule selects assets, that the configuration and the parent asset
+// exist and that the attribute is defined in the attribute schema of the asset type if one is given.
+func validateMappingRule(ctx context.Context, dbMappingRule *appdb.MappingRule) error {
+	validationErr := &ValidationError{}
+	exists, err := appdb.ConfigurationExistsG(ctx, dbMappingRule.ConfigID)
+	if err != nil {
+		return fmt.Errorf("checking configuration: %v", err)
+	}
+	if !exists {
+		validationErr.Add("configId", "configuration %d doesn't exist", dbMappingRule.ConfigID)
+	}
+	if !dbMappingRule.AssetType.Valid && !dbMappingRule.TagExpression.Valid && !dbMappingRule.ProjectID.Valid && !dbMappingRule.ParentAssetID.Valid {
+		validationErr.Add("assetType", "is required if neither tagExpression, projectId nor parentAssetId is given")
+	}
+	if dbMappingRule.TagExpression.Valid {
+		if _, err := parseTagExpression(dbMappingRule.TagExpression.String); err != nil {
+			validationErr.Add("tagExpression", "%v", err)
+		}
+	}
+	if dbMappingRule.ParentAssetID.Valid {
+		apiAsset, err := eliona.GetAssetById(dbMappingRule.ParentAssetID.Int32)
+		if err != nil {
+			return fmt.Errorf("getting parent asset from Eliona: %w", err)
+		}
+		if apiAsset == nil {
+			validationErr.Add("parentAssetId", "asset %d doesn't exist", dbMappingRule.ParentAssetID.Int32)
+		}
+	} else if dbMappingRule.IncludeDescendants {
+		validationErr.Add("includeDescendants", "requires parentAssetId")
+	}
+	if dbMappingRule.AssetType.Valid {
+		assetType, err := eliona.GetAssetType(dbMappingRule.AssetType.String)
+		if err != nil {
+			return err
+		}
+		validateAttributeSchema(validationErr, "assetType", dbMappingRule.AssetType.String, assetType, dbMappingRule.Subtype, dbMappingRule.AttributeName)
+	} else {
+		validateSubtype(validationErr, dbMappingRule.Subtype)
+	}
+	validatePrecision(validationErr, dbMappingRule.Precision)
+	return validationErr.OrNil()
+}
+
+func getDbMappingRule(ctx context.Context, ruleId int64) (*appdb.MappingRule, error) {
+	dbMappingRule, err := appdb.FindMappingRuleG(ctx, ruleId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching mapping rule from database: %v", err)
+	}
+	return dbMappingRule, nil
+}
+
+func dbMappingRuleFromApiMappingRule(apiMappingRule apiserver.MappingRule) *appdb.MappingRule {
+	dbMappingRule := new(appdb.MappingRule)
+	dbMappingRule.ConfigID = int64(apiMappingRule.ConfigId)
+	dbMappingRule.AssetType = optionalString(apiMappingRule.AssetType)
+	dbMappingRule.ParentAssetID = null.Int32FromPtr(apiMappingRule.ParentAssetId)
+	dbMappingRule.IncludeDescendants = common.Val(apiMappingRule.IncludeDescendants)
+	dbMappingRule.ProjectID = optionalString(apiMappingRule.ProjectId)
+	dbMappingRule.TagExpression = optionalString(apiMappingRule.TagExpression)
+	dbMappingRule.Subtype = apiMappingRule.Subtype
+	dbMappingRule.AttributeName = apiMappingRule.AttributeName
+	dbMappingRule.Precision = null.Int32FromPtr(apiMappingRule.Precision)
+	dbMappingRule.Enable = true
+	if apiMappingRule.Enable != nil {
+		dbMappingRule.Enable = *apiMappingRule.Enable
+	}
+	return dbMappingRule
+}
+
+func apiMappingRuleFromDbMappingRule(ctx context.Context, dbMappingRule *appdb.MappingRule) (*apiserver.MappingRule, error) {
+	mappings, err := appdb.AssetAttributes(
+		appdb.AssetAttributeWhere.RuleID.EQ(null.Int64From(dbMappingRule.ID)),
+	).CountG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("counting asset attributes of mapping rule: %v", err)
+	}
+	apiMappingRule := new(apiserver.MappingRule)
+	apiMappingRule.Id = common.Ptr(dbMappingRule.ID)
+	apiMappingRule.ConfigId = int32(dbMappingRule.ConfigID)
+	apiMappingRule.AssetType = dbMappingRule.AssetType.Ptr()
+	apiMappingRule.ParentAssetId = dbMappingRule.ParentAssetID.Ptr()
+	apiMappingRule.IncludeDescendants = common.Ptr(dbMappingRule.IncludeDescendants)
+	apiMappingRule.ProjectId = dbMappingRule.ProjectID.Ptr()
+	apiMappingRule.TagExpression = dbMappingRule.TagExpression.Ptr()
+	apiMappingRule.Subtype = dbMappingRule.Subtype
+	apiMappingRule.AttributeName = dbMappingRule.AttributeName
+	apiMappingRule.Precision = dbMappingRule.Precision.Ptr()
+	apiMappingRule.Enable = common.Ptr(dbMappingRule.Enable)
+	apiMappingRule.Mappings = common.Ptr(int32(mappings))
+	apiMappingRule.LastReconcileTimestamp = dbMappingRule.LastReconcileTS.Ptr()
+	apiMappingRule.LastError = dbMappingRule.LastError.Ptr()
+	apiMappingRule.CreatedAt = common.Ptr(dbMappingRule.CreatedAt)
+	return apiMappingRule, nil
+}
+
+// optionalString treats empty strings like missing ones.
+func optionalString(value *string) null.String {
+	if value == nil || *value == "" {
+		return null.String{}
+	}
+	return null.StringFrom(*value)
+}
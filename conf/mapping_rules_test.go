@@ -0,0 +1,102 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"zevvy/appdb"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/volatiletech/null/v8"
+)
+
+// One asset whose references can't be rendered is skipped without blocking the other assets of the rule.
+func TestSelectAssetAttributes(t *testing.T) {
+	assets := map[int32]map[string]any{
+		1: {"id": 1, "globalAssetIdentifier": "meter1", "assetType": "meter", "projectId": "1", "tags": []string{"building=A"}},
+		2: {"id": 2, "globalAssetIdentifier": "meter2", "assetType": "meter", "projectId": "1", "tags": []string{}},
+		3: {"id": 3, "globalAssetIdentifier": "meter3", "assetType": "meter", "projectId": "1", "tags": []string{"building=B"}},
+	}
+	tests := []struct {
+		name        string
+		assetIds    []int32
+		wantDevices map[int32]string
+		wantSkipped []int32
+	}{
+		{name: "all assets render", assetIds: []int32{1, 3}, wantDevices: map[int32]string{1: "A", 3: "B"}},
+		{name: "one asset fails to render", assetIds: []int32{1, 2, 3}, wantDevices: map[int32]string{1: "A", 3: "B"}, wantSkipped: []int32{2}},
+		{name: "only asset fails to render", assetIds: []int32{2}, wantDevices: map[int32]string{}, wantSkipped: []int32{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiAssets []map[string]any
+			for _, assetId := range tt.assetIds {
+				apiAssets = append(apiAssets, assets[assetId])
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/asset-types/meter"):
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"name":       "meter",
+						"attributes": []map[string]any{{"name": "energy", "subtype": "input"}},
+					})
+				case strings.HasSuffix(r.URL.Path, "/assets"):
+					_ = json.NewEncoder(w).Encode(apiAssets)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+			t.Setenv("API_ENDPOINT", server.URL)
+			mock, _ := mockDB(t)
+			mock.ExpectQuery("select").WillReturnRows(sqlmock.NewRows([]string{"id", "device_reference_template"}).AddRow(int64(1), "{tag:building=}"))
+
+			dbMappingRule := &appdb.MappingRule{ID: 1, ConfigID: 1, AssetType: null.StringFrom("meter"), Subtype: "input", AttributeName: "energy"}
+			selected, skipped, err := selectAssetAttributes(t.Context(), dbMappingRule)
+			if err != nil {
+				t.Fatalf("selecting asset attributes: %v", err)
+			}
+			devices := make(map[int32]string)
+			for assetId, dbAssetAttribute := range selected {
+				devices[assetId] = dbAssetAttribute.DeviceReference
+			}
+			if len(devices) != len(tt.wantDevices) {
+				t.Errorf("selected devices %v, want %v", devices, tt.wantDevices)
+			}
+			for assetId, device := range tt.wantDevices {
+				if devices[assetId] != device {
+					t.Errorf("selected devices %v, want %v", devices, tt.wantDevices)
+				}
+			}
+			var skippedIds []int32
+			for _, skippedAsset := range skipped {
+				skippedIds = append(skippedIds, skippedAsset.AssetId)
+				if skippedAsset.Message == "" {
+					t.Errorf("asset %d skipped without message", skippedAsset.AssetId)
+				}
+			}
+			if !slices.Equal(skippedIds, tt.wantSkipped) {
+				t.Errorf("skipped assets %v, want %v", skippedIds, tt.wantSkipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"zevvy/appdb"
+	"zevvy/model"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// EnqueueMeasurements stores the measurements in the outbox and advances the latest timestamp of the
+// asset attribute in one transaction. Once queued, the measurements survive restarts until they are
+// sent to Zevvy. Measurements already queued are ignored.
+func EnqueueMeasurements(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute, measurements []model.Measurement, latestTimestamp time.Time) error {
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, measurement := range measurements {
+		dbOutbox := &appdb.Outbox{
+			ConfigID:      dbAssetAttribute.ConfigID,
+			AssetID:       dbAssetAttribute.AssetID,
+			Subtype:       dbAssetAttribute.Subtype,
+			AttributeName: dbAssetAttribute.AttributeName,
+			ReadAt:        measurement.Timestamp,
+			Value:         *measurement.Value,
+		}
+		err := dbOutbox.Upsert(ctx, tx, false,
+			[]string{
+				appdb.OutboxColumns.ConfigID,
+				appdb.OutboxColumns.AssetID,
+				appdb.OutboxColumns.Subtype,
+				appdb.OutboxColumns.AttributeName,
+				appdb.OutboxColumns.ReadAt,
+			},
+			boil.None(),
+			boil.Infer(),
+		)
+		if err != nil {
+			return fmt.Errorf("inserting outbox: %v", err)
+		}
+	}
+
+	if latestTimestamp.After(dbAssetAttribute.LatestTS) {
+		dbAssetAttribute.LatestTS = latestTimestamp
+		_, err = dbAssetAttribute.Update(ctx, tx, boil.Whitelist(appdb.AssetAttributeColumns.LatestTS))
+		if err != nil {
+			return fmt.Errorf("updating latest timestamp: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetDbOutbox returns the oldest measurements queued for the asset attribute.
+func GetDbOutbox(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute, limit int) ([]*appdb.Outbox, error) {
+	return appdb.Outboxes(
+		appdb.OutboxWhere.ConfigID.EQ(dbAssetAttribute.ConfigID),
+		appdb.OutboxWhere.AssetID.EQ(dbAssetAttribute.AssetID),
+		appdb.OutboxWhere.Subtype.EQ(dbAssetAttribute.Subtype),
+		appdb.OutboxWhere.AttributeName.EQ(dbAssetAttribute.AttributeName),
+		qm.OrderBy(appdb.OutboxColumns.ReadAt),
+		qm.Limit(limit),
+	).AllG(ctx)
+}
+
+// AckOutbox removes the measurements sent to Zevvy from the outbox and adds them to the total
+// number of measurements sent for the asset attribute.
+func AckOutbox(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute, dbOutboxes []*appdb.Outbox) error {
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	count, err := appdb.Outboxes(appdb.OutboxWhere.ID.IN(outboxIds(dbOutboxes))).DeleteAll(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("deleting outbox: %v", err)
+	}
+	if err := addAssetAttributeSent(ctx, tx, dbAssetAttribute, count); err != nil {
+		return fmt.Errorf("updating total sent: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+// UpdateOutboxError stores the error of the last attempt to send the measurements.
+func UpdateOutboxError(ctx context.Context, dbOutboxes []*appdb.Outbox, sendErr error) error {
+	_, err := appdb.Outboxes(appdb.OutboxWhere.ID.IN(outboxIds(dbOutboxes))).UpdateAllG(ctx, appdb.M{
+		appdb.OutboxColumns.LastAttemptTS: null.TimeFrom(time.Now()),
+		appdb.OutboxColumns.LastError:     null.StringFrom(sendErr.Error()),
+	})
+	return err
+}
+
+// DeadLetterOutbox moves measurements rejected by Zevvy from the outbox to the dead letters, so
+// they no longer block the measurements queued after them.
+func DeadLetterOutbox(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute, dbOutboxes []*appdb.Outbox, statusCode int, rejectErr error, responseBody string) error {
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, dbOutbox := range dbOutboxes {
+		dbDeadLetter := &appdb.DeadLetter{
+			ConfigID:          dbOutbox.ConfigID,
+			AssetID:           dbOutbox.AssetID,
+			Subtype:           dbOutbox.Subtype,
+			AttributeName:     dbOutbox.AttributeName,
+			DeviceReference:   dbAssetAttribute.DeviceReference,
+			RegisterReference: dbAssetAttribute.RegisterReference,
+			ReadAt:            dbOutbox.ReadAt,
+			Value:             dbOutbox.Value,
+			Error:             rejectErr.Error(),
+			ResponseBody:      null.NewString(responseBody, len(responseBody) > 0),
+		}
+		if statusCode > 0 {
+			dbDeadLetter.StatusCode = null.Int32From(int32(statusCode))
+		}
+		if err := dbDeadLetter.Insert(ctx, tx, boil.Infer()); err != nil {
+			return fmt.Errorf("inserting dead letter: %v", err)
+		}
+	}
+	_, err = appdb.Outboxes(appdb.OutboxWhere.ID.IN(outboxIds(dbOutboxes))).DeleteAll(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("deleting outbox: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+// addAssetAttributeSent adds the number of measurements sent to the total of the asset attribute. The
+// total is incremented in the database, because backfill jobs and the regular sync run concurrently.
+func addAssetAttributeSent(ctx context.Context, exec boil.ContextExecutor, dbAssetAttribute *appdb.AssetAttribute, count int64) error {
+	_, err := queries.Raw(`update zevvy.asset_attribute set total_sent = total_sent + $1
+		where config_id = $2 and asset_id = $3 and subtype = $4 and attribute_name = $5`,
+		count, dbAssetAttribute.ConfigID, dbAssetAttribute.AssetID, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName,
+	).ExecContext(ctx, exec)
+	if err != nil {
+		return err
+	}
+	dbAssetAttribute.TotalSent += count
+	return nil
+}
+
+func outboxIds(dbOutboxes []*appdb.Outbox) []int64 {
+	var ids []int64
+	for _, dbOutbox := range dbOutboxes {
+		ids = append(ids, dbOutbox.ID)
+	}
+	return ids
+}
@@ -0,0 +1,192 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"zevvy/apiserver"
+	"zevvy/appdb"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// referenceConstraint is the unique constraint on the references of the asset attributes of a configuration.
+const referenceConstraint = "asset_attribute_reference_key"
+
+// ReferenceCollisionError is returned if the references of an asset attribute are already used by another
+// asset attribute of the configuration. Their measurements would be merged into one register in Zevvy.
+// The collision lists the asset attribute to be stored first. It matches ErrConflict.
+type ReferenceCollisionError struct {
+	Collision apiserver.ReferenceCollision
+}
+
+func (e *ReferenceCollisionError) Error() string {
+	return fmt.Sprintf("device reference %q and register reference %q are already used by %d other asset attributes of configuration %d",
+		e.Collision.DeviceReference, e.Collision.RegisterReference, len(e.Collision.AssetAttributes)-1, e.Collision.ConfigId)
+}
+
+func (e *ReferenceCollisionError) Unwrap() error {
+	return ErrConflict
+}
+
+// ValidationError describes the collision with each other asset attribute as invalid field.
+func (e *ReferenceCollisionError) ValidationError() *ValidationError {
+	validationErr := &ValidationError{}
+	for _, other := range e.Collision.AssetAttributes[1:] {
+		validationErr.Add("deviceReference", "%q with register reference %q is already used by asset %d, attribute %s of subtype %s",
+			e.Collision.DeviceReference, e.Collision.RegisterReference, other.AssetId, other.AttributeName, other.Subtype)
+	}
+	return validationErr
+}
+
+// referenceKey identifies the register in Zevvy the measurements of an asset attribute are sent to.
+type referenceKey struct {
+	configId          int64
+	deviceReference   string
+	registerReference string
+}
+
+func referenceKeyOf(dbAssetAttribute *appdb.AssetAttribute) referenceKey {
+	return referenceKey{
+		configId:          dbAssetAttribute.ConfigID,
+		deviceReference:   dbAssetAttribute.DeviceReference,
+		registerReference: dbAssetAttribute.RegisterReference,
+	}
+}
+
+func sameAssetAttribute(a *appdb.AssetAttribute, b *appdb.AssetAttribute) bool {
+	return a.ConfigID == b.ConfigID && a.AssetID == b.AssetID && a.Subtype == b.Subtype && a.AttributeName == b.AttributeName
+}
+
+// lockReferences locks the configuration until the end of the transaction. The references of its asset
+// attributes are checked for collisions and written while holding the lock, so concurrent writes can't
+// store the same references in between.
+func lockReferences(ctx context.Context, tx boil.ContextExecutor, configId int64) error {
+	_, err := appdb.Configurations(
+		appdb.ConfigurationWhere.ID.EQ(configId),
+		qm.For("update"),
+	).One(ctx, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("locking configuration: %v", err)
+	}
+	return nil
+}
+
+// checkReferenceCollision checks that no other asset attribute of the configuration uses the references of
+// the asset attribute. Otherwise, a ReferenceCollisionError listing all of them is returned. To rely on the
+// result for a write, the configuration must be locked with lockReferences in the same transaction.
+func checkReferenceCollision(ctx context.Context, exec boil.ContextExecutor, dbAssetAttribute *appdb.AssetAttribute) error {
+	dbAssetAttributes, err := appdb.AssetAttributes(
+		appdb.AssetAttributeWhere.ConfigID.EQ(dbAssetAttribute.ConfigID),
+		appdb.AssetAttributeWhere.DeviceReference.EQ(dbAssetAttribute.DeviceReference),
+		appdb.AssetAttributeWhere.RegisterReference.EQ(dbAssetAttribute.RegisterReference),
+		qm.OrderBy(appdb.AssetAttributeColumns.AssetID),
+	).All(ctx, exec)
+	if err != nil {
+		return fmt.Errorf("checking reference collisions: %v", err)
+	}
+	collision := referenceCollision(referenceKeyOf(dbAssetAttribute), dbAssetAttribute)
+	for _, other := range dbAssetAttributes {
+		if !sameAssetAttribute(other, dbAssetAttribute) {
+			collision.AssetAttributes = append(collision.AssetAttributes, *apiAssetAttributeFromDbAssetAttribute(other))
+		}
+	}
+	if len(collision.AssetAttributes) == 1 {
+		return nil
+	}
+	return &ReferenceCollisionError{Collision: collision}
+}
+
+func referenceCollision(key referenceKey, dbAssetAttributes ...*appdb.AssetAttribute) apiserver.ReferenceCollision {
+	collision := apiserver.ReferenceCollision{
+		ConfigId:          key.configId,
+		DeviceReference:   key.deviceReference,
+		RegisterReference: key.registerReference,
+		AssetAttributes:   []apiserver.AssetAttribute{},
+	}
+	for _, dbAssetAttribute := range dbAssetAttributes {
+		collision.AssetAttributes = append(collision.AssetAttributes, *apiAssetAttributeFromDbAssetAttribute(dbAssetAttribute))
+	}
+	return collision
+}
+
+// GetReferenceCollisions lists the asset attributes sharing their device and register reference with other
+// asset attributes of the same configuration, e.g. because slashes were replaced or because assets of
+// different projects have the same GAI. Collisions stored before the checks existed are found this way.
+func GetReferenceCollisions(ctx context.Context, configId int32) ([]apiserver.ReferenceCollision, error) {
+	var mods []qm.QueryMod
+	if configId > 0 {
+		mods = append(mods, appdb.AssetAttributeWhere.ConfigID.EQ(int64(configId)))
+	}
+	mods = append(mods, qm.OrderBy(appdb.AssetAttributeColumns.ConfigID+", "+appdb.AssetAttributeColumns.DeviceReference+", "+
+		appdb.AssetAttributeColumns.RegisterReference+", "+appdb.AssetAttributeColumns.AssetID))
+	dbAssetAttributes, err := appdb.AssetAttributes(mods...).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching asset attributes: %v", err)
+	}
+	collisions := []apiserver.ReferenceCollision{}
+	for start := 0; start < len(dbAssetAttributes); {
+		key := referenceKeyOf(dbAssetAttributes[start])
+		end := start + 1
+		for end < len(dbAssetAttributes) && referenceKeyOf(dbAssetAttributes[end]) == key {
+			end++
+		}
+		if end-start > 1 {
+			collisions = append(collisions, referenceCollision(key, dbAssetAttributes[start:end]...))
+		}
+		start = end
+	}
+	return collisions, nil
+}
+
+// AddReferenceConstraint adds the unique constraint on the references of the asset attributes, if it is
+// missing and no collisions are left. Installations updated with collisions get the constraint once they
+// are resolved, as this is done on every start.
+func AddReferenceConstraint(ctx context.Context) error {
+	var exists bool
+	err := queries.Raw(`select exists (select from pg_constraint where conname = $1)`, referenceConstraint).
+		QueryRowContext(ctx, boil.GetContextDB()).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("checking reference constraint: %v", err)
+	}
+	if exists {
+		return nil
+	}
+	collisions, err := GetReferenceCollisions(ctx, 0)
+	if err != nil {
+		return err
+	}
+	if len(collisions) > 0 {
+		log.Warn("conf", "%d reference collisions found. Resolve them to let the app prevent new ones in the database.", len(collisions))
+		return nil
+	}
+	_, err = queries.Raw(`alter table zevvy.asset_attribute
+		add constraint `+referenceConstraint+` unique (config_id, device_reference, register_reference)
+			deferrable initially deferred`).ExecContext(ctx, boil.GetContextDB())
+	if err != nil {
+		return fmt.Errorf("adding reference constraint: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"zevvy/appdb"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/volatiletech/null/v8"
+)
+
+// The collision check and the inserts of a reconciliation run in one transaction holding the lock on the
+// configuration, so no concurrent write can take the references in between.
+func TestCreateAssetAttributesLocksReferences(t *testing.T) {
+	mock, recorder := mockDB(t)
+	selected := map[int32]*appdb.AssetAttribute{
+		1: {ConfigID: 1, AssetID: 1, Subtype: "input", AttributeName: "energy", DeviceReference: "meter", RegisterReference: "energy", RuleID: null.Int64From(1)},
+		2: {ConfigID: 1, AssetID: 2, Subtype: "input", AttributeName: "energy", DeviceReference: "other", RegisterReference: "energy", RuleID: null.Int64From(1)},
+	}
+	attributeColumns := []string{"config_id", "asset_id", "subtype", "attribute_name", "device_reference", "register_reference"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("lock").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("exists").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("collisions").WillReturnRows(sqlmock.NewRows(attributeColumns).AddRow(1, 3, "input", "energy", "meter", "energy"))
+	mock.ExpectQuery("exists").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("collisions").WillReturnRows(sqlmock.NewRows(attributeColumns))
+	mock.ExpectQuery("insert").WillReturnRows(sqlmock.NewRows([]string{"latest_ts", "precision", "last_attempt_ts", "last_success_ts", "last_error",
+		"consecutive_failures", "total_sent", "device_reference_rendered", "register_reference_rendered", "retired_ts"}).
+		AddRow(time.Now(), nil, nil, nil, nil, 0, 0, nil, nil, nil))
+	mock.ExpectCommit()
+
+	created, collisions, err := createAssetAttributes(t.Context(), 1, selected)
+	if err != nil {
+		t.Fatalf("creating asset attributes: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	if created != 1 {
+		t.Errorf("created = %d, want 1", created)
+	}
+	if len(collisions) != 1 || len(collisions[0].AssetAttributes) != 2 ||
+		collisions[0].AssetAttributes[0].AssetId != 1 || collisions[0].AssetAttributes[1].AssetId != 3 {
+		t.Errorf("collisions = %+v, want asset 1 colliding with asset 3", collisions)
+	}
+	statements := recorder.all()
+	if !strings.HasSuffix(statements[0], "FOR update;") {
+		t.Errorf("first statement doesn't lock the configuration: %s", statements[0])
+	}
+	if !strings.HasPrefix(statements[len(statements)-1], "INSERT") {
+		t.Errorf("last statement isn't the insert: %s", statements[len(statements)-1])
+	}
+}
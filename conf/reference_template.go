@@ -0,0 +1,330 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"zevvy/appdb"
+	"zevvy/eliona"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/volatiletech/null/v8"
+)
+
+// Default templates of the references, if the configuration defines none.
+const (
+	DefaultDeviceReferenceTemplate   = "{gai}"
+	DefaultRegisterReferenceTemplate = "{attribute}"
+)
+
+// Placeholders of the reference templates. Besides these, {tag:<prefix>} renders the rest of the first asset
+// tag starting with the prefix, e.g. {tag:building=} renders "A" for the tag "building=A".
+const (
+	placeholderGai       = "gai"
+	placeholderAssetId   = "assetId"
+	placeholderAssetName = "assetName"
+	placeholderAssetType = "assetType"
+	placeholderProject   = "project"
+	placeholderParentGai = "parentGai"
+	placeholderTags      = "tags"
+	placeholderAttribute = "attribute"
+	placeholderSubtype   = "subtype"
+	placeholderUnit      = "unit"
+	placeholderTagPrefix = "tag:"
+)
+
+var referencePlaceholders = []string{
+	placeholderGai, placeholderAssetId, placeholderAssetName, placeholderAssetType, placeholderProject,
+	placeholderParentGai, placeholderTags, placeholderAttribute, placeholderSubtype, placeholderUnit,
+}
+
+// referenceTemplate is a parsed reference template. Each part is either literal text or a placeholder.
+type referenceTemplate []templatePart
+
+type templatePart struct {
+	literal     string
+	placeholder string
+}
+
+// parseReferenceTemplate parses a template for device or register references. Placeholders are written in
+// braces, e.g. "{project}-{gai}". Literal braces are escaped by doubling them.
+func parseReferenceTemplate(template string) (referenceTemplate, error) {
+	var parsed referenceTemplate
+	var literal strings.Builder
+	runes := []rune(template)
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '{' && i+1 < len(runes) && runes[i+1] == '{',
+			runes[i] == '}' && i+1 < len(runes) && runes[i+1] == '}':
+			literal.WriteRune(runes[i])
+			i++
+		case runes[i] == '}':
+			return nil, fmt.Errorf("has an unexpected \"}\"")
+		case runes[i] == '{':
+			end := slices.Index(runes[i+1:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("has an unclosed placeholder")
+			}
+			placeholder := string(runes[i+1 : i+1+end])
+			if !isReferencePlaceholder(placeholder) {
+				return nil, fmt.Errorf("has an unknown placeholder {%s}", placeholder)
+			}
+			if literal.Len() > 0 {
+				parsed = append(parsed, templatePart{literal: literal.String()})
+				literal.Reset()
+			}
+			parsed = append(parsed, templatePart{placeholder: placeholder})
+			i += end + 1
+		default:
+			literal.WriteRune(runes[i])
+		}
+	}
+	if literal.Len() > 0 {
+		parsed = append(parsed, templatePart{literal: literal.String()})
+	}
+	return parsed, nil
+}
+
+func isReferencePlaceholder(placeholder string) bool {
+	if prefix, ok := strings.CutPrefix(placeholder, placeholderTagPrefix); ok {
+		return prefix != ""
+	}
+	return slices.Contains(referencePlaceholders, placeholder)
+}
+
+func (t referenceTemplate) uses(placeholder string) bool {
+	return slices.ContainsFunc(t, func(part templatePart) bool { return part.placeholder == placeholder })
+}
+
+// referenceValues are the values of the placeholders for one asset attribute.
+type referenceValues struct {
+	asset     *api.Asset
+	parentGai string
+	attribute string
+	subtype   string
+	unit      string
+}
+
+func (t referenceTemplate) render(values referenceValues) string {
+	var reference strings.Builder
+	for _, part := range t {
+		reference.WriteString(part.literal)
+		reference.WriteString(strings.Trim(values.get(part.placeholder), " "))
+	}
+	return normalizeReference(reference.String())
+}
+
+func (values referenceValues) get(placeholder string) string {
+	switch placeholder {
+	case placeholderGai:
+		return values.asset.GlobalAssetIdentifier
+	case placeholderAssetId:
+		return fmt.Sprint(values.asset.GetId())
+	case placeholderAssetName:
+		return values.asset.GetName()
+	case placeholderAssetType:
+		return values.asset.AssetType
+	case placeholderProject:
+		return values.asset.ProjectId
+	case placeholderParentGai:
+		return values.parentGai
+	case placeholderTags:
+		return strings.Join(values.asset.Tags, "-")
+	case placeholderAttribute:
+		return values.attribute
+	case placeholderSubtype:
+		return values.subtype
+	case placeholderUnit:
+		return values.unit
+	}
+	if prefix, ok := strings.CutPrefix(placeholder, placeholderTagPrefix); ok {
+		for _, tag := range values.asset.Tags {
+			if value, found := strings.CutPrefix(tag, prefix); found {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeReference removes surrounding spaces and replaces slashes, because Zevvy doesn't allow them.
+func normalizeReference(reference string) string {
+	return strings.ReplaceAll(strings.Trim(reference, " "), "/", "_")
+}
+
+// validateReferenceTemplate checks a reference template of a configuration. An empty template selects the
+// default template.
+func validateReferenceTemplate(validationErr *ValidationError, field string, template *string) {
+	if template == nil || *template == "" {
+		return
+	}
+	if _, err := parseReferenceTemplate(*template); err != nil {
+		validationErr.Add(field, "%v", err)
+	}
+}
+
+// referenceTemplateOrDefault returns the stored template or the default template if none is stored.
+func referenceTemplateOrDefault(template null.String, defaultTemplate string) string {
+	if template.String == "" {
+		return defaultTemplate
+	}
+	return template.String
+}
+
+// referenceRenderer renders the references of the asset attributes of one configuration. Parent assets and
+// asset types needed by the templates are fetched from Eliona once per renderer.
+type referenceRenderer struct {
+	deviceTemplate   referenceTemplate
+	registerTemplate referenceTemplate
+	parentGais       map[int32]string
+	assetTypes       map[string]*api.AssetType
+}
+
+func newReferenceRenderer(ctx context.Context, configId int64) (*referenceRenderer, error) {
+	dbConfig, err := GetDbConfig(ctx, configId)
+	if err != nil {
+		return nil, err
+	}
+	deviceTemplate, err := parseReferenceTemplate(referenceTemplateOrDefault(dbConfig.DeviceReferenceTemplate, DefaultDeviceReferenceTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("device reference template of configuration %d %v", configId, err)
+	}
+	registerTemplate, err := parseReferenceTemplate(referenceTemplateOrDefault(dbConfig.RegisterReferenceTemplate, DefaultRegisterReferenceTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("register reference template of configuration %d %v", configId, err)
+	}
+	return &referenceRenderer{
+		deviceTemplate:   deviceTemplate,
+		registerTemplate: registerTemplate,
+		parentGais:       make(map[int32]string),
+		assetTypes:       make(map[string]*api.AssetType),
+	}, nil
+}
+
+// setReferences sets the device and register reference of the asset attribute. Missing references are
+// rendered from the templates of the configuration, given references are kept. Whether a reference was
+// rendered is stored, so only rendered references are rendered again after a template changed.
+func (r *referenceRenderer) setReferences(dbAssetAttribute *appdb.AssetAttribute, apiAsset *api.Asset) error {
+	deviceRendered := len(dbAssetAttribute.DeviceReference) == 0
+	registerRendered := len(dbAssetAttribute.RegisterReference) == 0
+	deviceReference, registerReference, err := r.render(dbAssetAttribute, apiAsset, deviceRendered, registerRendered)
+	if err != nil {
+		return err
+	}
+	dbAssetAttribute.DeviceReference = deviceReference
+	dbAssetAttribute.RegisterReference = registerReference
+	dbAssetAttribute.DeviceReferenceRendered = null.BoolFrom(deviceRendered)
+	dbAssetAttribute.RegisterReferenceRendered = null.BoolFrom(registerRendered)
+	return nil
+}
+
+// render returns the references of the asset attribute. References not to be rendered are returned
+// normalized. A template rendering an empty reference is reported as ValidationError.
+func (r *referenceRenderer) render(dbAssetAttribute *appdb.AssetAttribute, apiAsset *api.Asset, renderDevice bool, renderRegister bool) (string, string, error) {
+	values := referenceValues{
+		asset:     apiAsset,
+		attribute: dbAssetAttribute.AttributeName,
+		subtype:   dbAssetAttribute.Subtype,
+	}
+	templates := referenceTemplate{}
+	if renderDevice {
+		templates = append(templates, r.deviceTemplate...)
+	}
+	if renderRegister {
+		templates = append(templates, r.registerTemplate...)
+	}
+	if templates.uses(placeholderParentGai) {
+		parentGai, err := r.parentGai(apiAsset)
+		if err != nil {
+			return "", "", err
+		}
+		values.parentGai = parentGai
+	}
+	if templates.uses(placeholderUnit) {
+		unit, err := r.unit(apiAsset, dbAssetAttribute)
+		if err != nil {
+			return "", "", err
+		}
+		values.unit = unit
+	}
+
+	validationErr := &ValidationError{}
+	deviceReference := strings.ReplaceAll(dbAssetAttribute.DeviceReference, "/", "_")
+	if renderDevice {
+		deviceReference = r.deviceTemplate.render(values)
+		if deviceReference == "" {
+			validationErr.Add("deviceReference", "template renders an empty reference for asset %d", apiAsset.GetId())
+		}
+	}
+	registerReference := strings.ReplaceAll(dbAssetAttribute.RegisterReference, "/", "_")
+	if renderRegister {
+		registerReference = r.registerTemplate.render(values)
+		if registerReference == "" {
+			validationErr.Add("registerReference", "template renders an empty reference for asset %d", apiAsset.GetId())
+		}
+	}
+	return deviceReference, registerReference, validationErr.OrNil()
+}
+
+// parentGai returns the GAI of the functional parent of the asset, or of the locational parent if the asset
+// has no functional parent.
+func (r *referenceRenderer) parentGai(apiAsset *api.Asset) (string, error) {
+	parentId := apiAsset.GetParentFunctionalAssetId()
+	if parentId == 0 {
+		parentId = apiAsset.GetParentLocationalAssetId()
+	}
+	if parentId == 0 {
+		return "", nil
+	}
+	if parentGai, ok := r.parentGais[parentId]; ok {
+		return parentGai, nil
+	}
+	parent, err := eliona.GetAssetById(parentId)
+	if err != nil {
+		return "", fmt.Errorf("getting parent asset %d from Eliona: %w", parentId, err)
+	}
+	var parentGai string
+	if parent != nil {
+		parentGai = parent.GlobalAssetIdentifier
+	}
+	r.parentGais[parentId] = parentGai
+	return parentGai, nil
+}
+
+// unit returns the unit of the attribute as defined in the attribute schema of the asset type.
+func (r *referenceRenderer) unit(apiAsset *api.Asset, dbAssetAttribute *appdb.AssetAttribute) (string, error) {
+	assetType, ok := r.assetTypes[apiAsset.AssetType]
+	if !ok {
+		var err error
+		assetType, err = eliona.GetAssetType(apiAsset.AssetType)
+		if err != nil {
+			return "", err
+		}
+		r.assetTypes[apiAsset.AssetType] = assetType
+	}
+	if assetType == nil {
+		return "", nil
+	}
+	for _, attribute := range assetType.Attributes {
+		if attribute.Name == dbAssetAttribute.AttributeName && string(attribute.Subtype) == dbAssetAttribute.Subtype {
+			return attribute.GetUnit(), nil
+		}
+	}
+	return "", nil
+}
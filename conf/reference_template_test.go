@@ -0,0 +1,79 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+func TestParseReferenceTemplate(t *testing.T) {
+	asset := &api.Asset{
+		Id:                    *api.NewNullableInt32(api.PtrInt32(4711)),
+		GlobalAssetIdentifier: "meter/01",
+		Name:                  *api.NewNullableString(api.PtrString(" Main meter ")),
+		ProjectId:             "99",
+		AssetType:             "zevvy_meter",
+		Tags:                  []string{"virtual", "building=A", "floor=1"},
+	}
+	values := referenceValues{asset: asset, parentGai: "site", attribute: "energy", subtype: "input", unit: "kWh"}
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  string
+	}{
+		{name: "default device reference", template: DefaultDeviceReferenceTemplate, want: "meter_01"},
+		{name: "default register reference", template: DefaultRegisterReferenceTemplate, want: "energy"},
+		{name: "literal", template: "main", want: "main"},
+		{name: "empty", template: "", want: ""},
+		{name: "combined", template: "{project}-{gai}", want: "99-meter_01"},
+		{name: "all placeholders", template: "{assetId}.{assetType}.{parentGai}.{attribute}.{subtype}.{unit}", want: "4711.zevvy_meter.site.energy.input.kWh"},
+		{name: "trimmed value", template: "[{assetName}]", want: "[Main meter]"},
+		{name: "tags", template: "{tags}", want: "virtual-building=A-floor=1"},
+		{name: "tag prefix", template: "{tag:building=}{tag:floor=}", want: "A1"},
+		{name: "missing tag prefix", template: "x{tag:room=}", want: "x"},
+		{name: "escaped braces", template: "{{gai}}", want: "{gai}"},
+		{name: "escaped braces around placeholder", template: "{{{gai}}}", want: "{meter_01}"},
+		{name: "unicode literal", template: "zähler-{gai}", want: "zähler-meter_01"},
+		{name: "unknown placeholder", template: "{project}-{name}", wantErr: "unknown placeholder {name}"},
+		{name: "case sensitive placeholder", template: "{GAI}", wantErr: "unknown placeholder {GAI}"},
+		{name: "empty placeholder", template: "{}", wantErr: "unknown placeholder {}"},
+		{name: "empty tag prefix", template: "{tag:}", wantErr: "unknown placeholder {tag:}"},
+		{name: "unclosed placeholder", template: "{project}-{gai", wantErr: "unclosed placeholder"},
+		{name: "unexpected closing brace", template: "gai}", wantErr: `unexpected "}"`},
+		{name: "unescaped closing brace after escape", template: "{{gai}}}", wantErr: `unexpected "}"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := parseReferenceTemplate(tt.template)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsing %q: %v", tt.template, err)
+			}
+			if got := template.render(values); got != tt.want {
+				t.Errorf("%q renders %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tagExpression reports whether a set of asset tags matches a parsed tag expression.
+type tagExpression func(tags map[string]bool) bool
+
+// parseTagExpression parses a boolean expression over asset tags. Tags are combined with & (and),
+// | (or) and ! (not) and grouped with parentheses, e.g. "meter & (floor1 | floor2) & !virtual".
+// & binds stronger than |. Tags containing spaces or operators must be quoted with double quotes.
+func parseTagExpression(expression string) (tagExpression, error) {
+	tokens, err := tokenizeTagExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("is empty")
+	}
+	parser := &tagExpressionParser{tokens: tokens}
+	matcher, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(parser.tokens) {
+		return nil, fmt.Errorf("has an unexpected %q", parser.tokens[parser.pos].text)
+	}
+	return matcher, nil
+}
+
+type tagToken struct {
+	text  string
+	isTag bool
+}
+
+func tokenizeTagExpression(expression string) ([]tagToken, error) {
+	var tokens []tagToken
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("&|!()", r):
+			tokens = append(tokens, tagToken{text: string(r)})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("has an unterminated quote")
+			}
+			tokens = append(tokens, tagToken{text: string(runes[i+1 : end]), isTag: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("&|!()\"", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, tagToken{text: string(runes[i:end]), isTag: true})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type tagExpressionParser struct {
+	tokens []tagToken
+	pos    int
+}
+
+func (p *tagExpressionParser) accept(operator string) bool {
+	if p.pos < len(p.tokens) && !p.tokens[p.pos].isTag && p.tokens[p.pos].text == operator {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *tagExpressionParser) parseOr() (tagExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("|") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(tags map[string]bool) bool { return l(tags) || right(tags) }
+	}
+	return left, nil
+}
+
+func (p *tagExpressionParser) parseAnd() (tagExpression, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(tags map[string]bool) bool { return l(tags) && right(tags) }
+	}
+	return left, nil
+}
+
+func (p *tagExpressionParser) parseNot() (tagExpression, error) {
+	if p.accept("!") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(tags map[string]bool) bool { return !operand(tags) }, nil
+	}
+	if p.accept("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("has an unclosed parenthesis")
+		}
+		return inner, nil
+	}
+	if p.pos == len(p.tokens) {
+		return nil, fmt.Errorf("ends unexpectedly")
+	}
+	token := p.tokens[p.pos]
+	if !token.isTag {
+		return nil, fmt.Errorf("has an unexpected %q", token.text)
+	}
+	p.pos++
+	return func(tags map[string]bool) bool { return tags[token.text] }, nil
+}
@@ -0,0 +1,78 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTagExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		tags       []string
+		want       bool
+		wantErr    string
+	}{
+		{name: "tag", expression: "meter", tags: []string{"meter"}, want: true},
+		{name: "missing tag", expression: "meter", tags: []string{"virtual"}},
+		{name: "and", expression: "meter & floor1", tags: []string{"meter", "floor1"}, want: true},
+		{name: "and with one tag", expression: "meter & floor1", tags: []string{"meter"}},
+		{name: "or", expression: "floor1 | floor2", tags: []string{"floor2"}, want: true},
+		{name: "not", expression: "!virtual", tags: []string{"meter"}, want: true},
+		{name: "double not", expression: "!!virtual", tags: []string{"virtual"}, want: true},
+		{name: "and binds stronger than or", expression: "floor1 | meter & virtual", tags: []string{"floor1"}, want: true},
+		{name: "and binds stronger than or on the left", expression: "meter & virtual | floor1", tags: []string{"meter"}},
+		{name: "not binds stronger than and", expression: "!virtual & meter", tags: []string{"virtual"}},
+		{name: "parentheses", expression: "(floor1 | meter) & virtual", tags: []string{"floor1"}},
+		{name: "nested parentheses", expression: "meter & !(floor1 | (floor2 & virtual))", tags: []string{"meter", "floor2"}, want: true},
+		{name: "without spaces", expression: "meter&(floor1|floor2)&!virtual", tags: []string{"meter", "floor1"}, want: true},
+		{name: "quoted tag with space", expression: `"main meter" & !virtual`, tags: []string{"main meter"}, want: true},
+		{name: "quoted tag with operators", expression: `"a&b|(c)"`, tags: []string{"a&b|(c)"}, want: true},
+		{name: "quoted tag is not split", expression: `"main meter"`, tags: []string{"main", "meter"}},
+		{name: "unicode tag", expression: "zähler", tags: []string{"zähler"}, want: true},
+		{name: "empty", expression: "  ", wantErr: "is empty"},
+		{name: "unterminated quote", expression: `"main meter`, wantErr: "unterminated quote"},
+		{name: "unclosed parenthesis", expression: "(meter | floor1", wantErr: "unclosed parenthesis"},
+		{name: "unexpected closing parenthesis", expression: "meter)", wantErr: `unexpected ")"`},
+		{name: "missing operand", expression: "meter &", wantErr: "ends unexpectedly"},
+		{name: "missing operator", expression: "meter floor1", wantErr: `unexpected "floor1"`},
+		{name: "leading operator", expression: "| meter", wantErr: `unexpected "|"`},
+		{name: "empty parentheses", expression: "()", wantErr: `unexpected ")"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := parseTagExpression(tt.expression)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsing %q: %v", tt.expression, err)
+			}
+			tags := make(map[string]bool)
+			for _, tag := range tt.tags {
+				tags[tag] = true
+			}
+			if got := match(tags); got != tt.want {
+				t.Errorf("%q matches %v = %v, want %v", tt.expression, tt.tags, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+--  This file is part of the eliona project.
+--  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+-- Brings installations created with version 1.0.0 up to the schema defined in init.sql.
+
+alter table zevvy.asset_attribute
+    add column if not exists precision integer,
+    add column if not exists last_attempt_ts timestamp with time zone,
+    add column if not exists last_success_ts timestamp with time zone,
+    add column if not exists last_error text,
+    add column if not exists consecutive_failures integer not null default 0,
+    add column if not exists total_sent bigint not null default 0;
+
+alter table zevvy.configuration
+    add column if not exists batch_size integer not null default 1000,
+    add column if not exists refresh_token_expire timestamp with time zone,
+    add column if not exists refresh_expire_notified boolean not null default false,
+    add column if not exists login_state text,
+    add column if not exists next_poll_ts timestamp with time zone,
+    add column if not exists auth_mode text not null default 'device_code',
+    add column if not exists version integer not null default 1;
+
+create table if not exists zevvy.backfill
+(
+    id             bigserial primary key,
+    config_id      bigint                   not null,
+    asset_id       integer                  not null,
+    subtype        text                     not null,
+    attribute_name text                     not null,
+    start_ts       timestamp with time zone not null,
+    end_ts         timestamp with time zone not null,
+    progress_ts    timestamp with time zone not null,
+    status         text                     not null default 'pending',
+    error          text,
+    created_at     timestamp with time zone not null default current_timestamp,
+    updated_at     timestamp with time zone not null default current_timestamp
+);
+
+create table if not exists zevvy.outbox
+(
+    id              bigserial primary key,
+    config_id       bigint                   not null,
+    asset_id        integer                  not null,
+    subtype         text                     not null,
+    attribute_name  text                     not null,
+    read_at         timestamp with time zone not null,
+    value           double precision         not null,
+    last_attempt_ts timestamp with time zone,
+    last_error      text,
+    created_at      timestamp with time zone not null default current_timestamp,
+    unique (config_id, asset_id, subtype, attribute_name, read_at)
+);
+
+create table if not exists zevvy.dead_letter
+(
+    id                 bigserial primary key,
+    config_id          bigint                   not null,
+    asset_id           integer                  not null,
+    subtype            text                     not null,
+    attribute_name     text                     not null,
+    device_reference   text                     not null,
+    register_reference text                     not null,
+    read_at            timestamp with time zone not null,
+    value              double precision         not null,
+    status_code        integer,
+    error              text                     not null,
+    response_body      text,
+    created_at         timestamp with time zone not null default current_timestamp
+);
+
+-- Asset attributes of deleted configurations were left behind before the foreign key existed.
+delete
+from zevvy.asset_attribute
+where not exists (select from zevvy.configuration where configuration.id = asset_attribute.config_id);
+
+alter table zevvy.asset_attribute
+    alter column config_id type bigint;
+alter table zevvy.backfill
+    alter column config_id type bigint;
+alter table zevvy.outbox
+    alter column config_id type bigint;
+alter table zevvy.dead_letter
+    alter column config_id type bigint;
+
+do
+$$
+    begin
+        if not exists (select from pg_constraint where conname = 'asset_attribute_config_id_fkey') then
+            alter table zevvy.asset_attribute
+                add constraint asset_attribute_config_id_fkey
+                    foreign key (config_id) references zevvy.configuration (id) on delete restrict;
+        end if;
+    end
+$$;
+
+create table if not exists zevvy.mapping_rule
+(
+    id                  bigserial primary key,
+    config_id           bigint                   not null references zevvy.configuration (id) on delete restrict,
+    asset_type          text,
+    parent_asset_id     integer,
+    include_descendants boolean                  not null default false,
+    project_id          text,
+    tag_expression      text,
+    subtype             text                     not null,
+    attribute_name      text                     not null,
+    precision           integer,
+    enable              boolean                  not null default true,
+    last_reconcile_ts   timestamp with time zone,
+    last_error          text,
+    created_at          timestamp with time zone not null default current_timestamp
+);
+
+alter table zevvy.asset_attribute
+    add column if not exists rule_id bigint references zevvy.mapping_rule (id) on delete set null;
+
+alter table zevvy.configuration
+    add column if not exists device_reference_template text,
+    add column if not exists register_reference_template text;
+
+alter table zevvy.asset_attribute
+    add column if not exists device_reference_rendered boolean,
+    add column if not exists register_reference_rendered boolean;
+
+alter table zevvy.asset_attribute
+    add column if not exists retired_ts timestamp with time zone;
+
+-- Register references rendered by the former default are recognized by the attribute name. Device references
+-- rendered from the GAI are recognized when the references are rendered again.
+update zevvy.asset_attribute
+set register_reference_rendered = (register_reference = replace(trim(attribute_name), '/', '_'))
+where register_reference_rendered is null;
+
+-- The unique constraint on the references of the asset attributes (asset_attribute_reference_key) is added by
+-- the app on start, as soon as no reference collisions are left.
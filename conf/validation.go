@@ -0,0 +1,106 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"zevvy/apiserver"
+	"zevvy/appdb"
+)
+
+// FieldError describes why the value of a field is invalid.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError lists all invalid fields of a request.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var messages []string
+	for _, field := range e.Fields {
+		messages = append(messages, fmt.Sprintf("%s %s", field.Field, field.Message))
+	}
+	return "invalid request: " + strings.Join(messages, ", ")
+}
+
+// Add adds an invalid field to the validation error.
+func (e *ValidationError) Add(field string, format string, args ...any) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// OrNil returns the validation error if there are invalid fields, otherwise nil.
+func (e *ValidationError) OrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ValidateConfig checks the configuration before it is stored. All invalid fields are returned
+// at once in a ValidationError.
+func ValidateConfig(config apiserver.Configuration) error {
+	validationErr := &ValidationError{}
+	validateRootUrl(validationErr, "authRootUrl", config.AuthRootUrl)
+	validateRootUrl(validationErr, "apiRootUrl", config.ApiRootUrl)
+	if config.ClientId == "" {
+		validationErr.Add("clientId", "is required")
+	}
+	if config.AuthMode != nil && !IsAuthModeValid(*config.AuthMode) {
+		validationErr.Add("authMode", "must be one of %s, %s or %s", AuthModeDeviceCode, AuthModeClientCredentials, AuthModeRefreshToken)
+	}
+	validatePositive(validationErr, "refreshInterval", config.RefreshInterval)
+	validatePositive(validationErr, "requestTimeout", config.RequestTimeout)
+	validatePositive(validationErr, "batchSize", config.BatchSize)
+	validateReferenceTemplate(validationErr, "deviceReferenceTemplate", config.DeviceReferenceTemplate)
+	validateReferenceTemplate(validationErr, "registerReferenceTemplate", config.RegisterReferenceTemplate)
+	return validationErr.OrNil()
+}
+
+func validateRootUrl(validationErr *ValidationError, field string, value string) {
+	if value == "" {
+		validationErr.Add(field, "is required")
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		validationErr.Add(field, "must be an absolute http or https URL")
+	}
+}
+
+func validatePositive(validationErr *ValidationError, field string, value *int32) {
+	if value != nil && *value <= 0 {
+		validationErr.Add(field, "must be greater than 0")
+	}
+}
+
+// validateSecrets checks the secrets of the configuration to be stored. Secrets are checked after the
+// stored secrets are taken over, because they are write-only and may be omitted on updates.
+func validateSecrets(dbConfig *appdb.Configuration) error {
+	validationErr := &ValidationError{}
+	if dbConfig.ClientSecret == "" {
+		validationErr.Add("clientSecret", "is required")
+	}
+	if dbConfig.AuthMode == AuthModeRefreshToken && dbConfig.RefreshToken.String == "" {
+		validationErr.Add("refreshToken", "is required for auth mode %s", AuthModeRefreshToken)
+	}
+	return validationErr.OrNil()
+}
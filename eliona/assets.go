@@ -19,21 +19,85 @@ import (
 	"fmt"
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"net/http"
+	"time"
 	"zevvy/appdb"
 )
 
-func GetDataList(dbAssetAttribute *appdb.AssetAttribute) ([]api.Data, error) {
-	dataList, response, err := client.NewClient().DataAPI.GetData(client.AuthenticationContext()).
-		AssetId(dbAssetAttribute.AssetID).
-		DataSubtype(dbAssetAttribute.Subtype).
-		Execute()
-	if err != nil {
-		return nil, fmt.Errorf("error fetching data trends from ELiona API %d: %w", response.StatusCode, err)
+// trendPageSize defines how many data trend entries are fetched from Eliona with one request.
+const trendPageSize = 1000
+
+// GetDataTrends returns all data trend entries stored in Eliona for the asset attribute between from and to
+// (both inclusive). The entries are fetched page by page until the whole window is read.
+func GetDataTrends(dbAssetAttribute *appdb.AssetAttribute, from time.Time, to time.Time) ([]api.Data, error) {
+	var dataList []api.Data
+	for offset := int32(0); ; offset += trendPageSize {
+		page, response, err := client.NewClient().DataAPI.GetDataTrendById(client.AuthenticationContext(), dbAssetAttribute.AssetID).
+			DataSubtype(dbAssetAttribute.Subtype).
+			AttributeName(dbAssetAttribute.AttributeName).
+			FromDate(from.Format(time.RFC3339)).
+			ToDate(to.Format(time.RFC3339)).
+			Offset(offset).
+			Size(trendPageSize).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("error fetching data trends from Eliona API %d: %w", statusCode(response), err)
+		}
+		dataList = append(dataList, page...)
+		if len(page) < trendPageSize {
+			return dataList, nil
+		}
+	}
+}
+
+func statusCode(response *http.Response) int {
+	if response == nil {
+		return 0
 	}
-	return dataList, nil
+	return response.StatusCode
 }
 
+// GetAsset returns the asset of the asset attribute. If the asset doesn't exist, nil is returned.
 func GetAsset(dbAssetAttribute *appdb.AssetAttribute) (*api.Asset, error) {
-	asset, _, err := client.NewClient().AssetsAPI.GetAssetById(client.AuthenticationContext(), dbAssetAttribute.AssetID).Execute()
+	return GetAssetById(dbAssetAttribute.AssetID)
+}
+
+// GetAssetById returns the asset with the given ID. If the asset doesn't exist, nil is returned.
+func GetAssetById(assetId int32) (*api.Asset, error) {
+	asset, response, err := client.NewClient().AssetsAPI.GetAssetById(client.AuthenticationContext(), assetId).Execute()
+	if statusCode(response) == http.StatusNotFound {
+		return nil, nil
+	}
 	return asset, err
 }
+
+// GetAssets returns all assets, filtered by asset type and project if given.
+func GetAssets(assetTypeName string, projectId string) ([]api.Asset, error) {
+	request := client.NewClient().AssetsAPI.GetAssets(client.AuthenticationContext())
+	if assetTypeName != "" {
+		request = request.AssetTypeName(assetTypeName)
+	}
+	if projectId != "" {
+		request = request.ProjectId(projectId)
+	}
+	assets, response, err := request.Execute()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching assets from Eliona API %d: %w", statusCode(response), err)
+	}
+	return assets, nil
+}
+
+// GetAssetType returns the asset type together with its attribute schema. If the asset type doesn't
+// exist, nil is returned.
+func GetAssetType(assetTypeName string) (*api.AssetType, error) {
+	assetType, response, err := client.NewClient().AssetTypesAPI.GetAssetTypeByName(client.AuthenticationContext(), assetTypeName).
+		Expansions([]string{"AssetType.attributes"}).
+		Execute()
+	if statusCode(response) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching asset type from Eliona API %d: %w", statusCode(response), err)
+	}
+	return assetType, nil
+}
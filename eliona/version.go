@@ -0,0 +1,34 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"fmt"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"time"
+)
+
+// CheckApi checks if the Eliona API is reachable by requesting its version.
+func CheckApi(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(client.AuthenticationContext(), timeout)
+	defer cancel()
+	_, response, err := client.NewClient().VersionAPI.GetVersion(ctx).Execute()
+	if err != nil {
+		return fmt.Errorf("error fetching version from Eliona API %d: %w", statusCode(response), err)
+	}
+	return nil
+}
@@ -8,6 +8,6 @@ docker run --rm ^
     --git-repo-id python-eliona-api-client ^
     -i /local/openapi.yaml ^
     -o /local/apiserver ^
-    --additional-properties="packageName=apiserver,sourceFolder=,outputAsLibrary=true"
+    --additional-properties="packageName=apiserver,sourceFolder=,outputAsLibrary=true,addResponseHeaders=true"
 
 goimports -w ./apiserver
@@ -0,0 +1,149 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"net/http"
+	"time"
+	"zevvy/appdb"
+	"zevvy/conf"
+	"zevvy/eliona"
+)
+
+const (
+	StatusOk       = "ok"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// checkTimeout limits the time each check of the readiness probe may take.
+const checkTimeout = 5 * time.Second
+
+// Report is the result of a health probe. The status is the worst status of all checks.
+type Report struct {
+	Status string  `json:"status"`
+	Checks []Check `json:"checks,omitempty"`
+}
+
+// Check is the result of checking one dependency of the app.
+type Check struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Message  string `json:"message,omitempty"`
+	ConfigId *int64 `json:"configId,omitempty"`
+}
+
+// LiveHandler reports that the app is running. It doesn't check any dependency, so
+// failing dependencies don't cause restarts of the app.
+func LiveHandler(w http.ResponseWriter, _ *http.Request) {
+	writeReport(w, Report{Status: StatusOk})
+}
+
+// ReadyHandler checks the database, the Eliona API and the login of each enabled configuration.
+// The app is not ready (503) if the database or the Eliona API is not reachable. Configurations
+// without valid access token degrade the status but don't make the app unready.
+func ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	report := Report{Status: StatusOk}
+	report.add(checkDatabase(r.Context()))
+	report.add(checkEliona())
+	if report.Status != StatusDown {
+		for _, check := range checkConfigs(r.Context()) {
+			report.add(check)
+		}
+	}
+	writeReport(w, report)
+}
+
+func (r *Report) add(check Check) {
+	r.Checks = append(r.Checks, check)
+	if severity(check.Status) > severity(r.Status) {
+		r.Status = check.Status
+	}
+}
+
+func severity(status string) int {
+	switch status {
+	case StatusDown:
+		return 2
+	case StatusDegraded:
+		return 1
+	}
+	return 0
+}
+
+func checkDatabase(ctx context.Context) Check {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	if _, err := queries.Raw("select 1").ExecContext(ctx, boil.GetContextDB()); err != nil {
+		return Check{Name: "database", Status: StatusDown, Message: err.Error()}
+	}
+	return Check{Name: "database", Status: StatusOk}
+}
+
+func checkEliona() Check {
+	if err := eliona.CheckApi(checkTimeout); err != nil {
+		return Check{Name: "eliona", Status: StatusDown, Message: err.Error()}
+	}
+	return Check{Name: "eliona", Status: StatusOk}
+}
+
+func checkConfigs(ctx context.Context) []Check {
+	dbConfigs, secretErrs, err := conf.LoadDbConfigs(ctx)
+	if err != nil {
+		return []Check{{Name: "configurations", Status: StatusDown, Message: err.Error()}}
+	}
+	var checks []Check
+	for _, secretErr := range secretErrs {
+		checks = append(checks, Check{Name: "zevvy", Status: StatusDegraded, Message: secretErr.Error(), ConfigId: &secretErr.ConfigId})
+	}
+	for _, dbConfig := range dbConfigs {
+		if conf.IsDbConfigEnabled(dbConfig) {
+			checks = append(checks, checkConfig(dbConfig))
+		}
+	}
+	return checks
+}
+
+func checkConfig(dbConfig *appdb.Configuration) Check {
+	check := Check{Name: "zevvy", Status: StatusOk, ConfigId: &dbConfig.ID}
+	switch {
+	case conf.IsLoginNeeded(dbConfig):
+		check.Status = StatusDegraded
+		check.Message = "login to Zevvy needed"
+	case !conf.IsAccessTokenIsValid(dbConfig):
+		check.Status = StatusDegraded
+		check.Message = fmt.Sprintf("access token expired at %v", dbConfig.AccessTokenExpire.Time.Format(time.RFC3339))
+	}
+	return check
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if report.Status == StatusDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Error("health", "Cannot write health report: %v", err)
+	}
+}
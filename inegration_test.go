@@ -17,5 +17,5 @@ func TestApp(t *testing.T) {
 func schema(t *testing.T) {
 	t.Parallel()
 
-	assert.SchemaExists(t, "zevvy", []string{"configuration", "asset_attribute"})
+	assert.SchemaExists(t, "zevvy", []string{"configuration", "asset_attribute", "backfill", "outbox", "dead_letter", "mapping_rule"})
 }
@@ -16,17 +16,23 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/eliona-smart-building-assistant/go-utils/db"
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"time"
+	"zevvy/conf"
 )
 
+var rotateKey = flag.Bool("rotate-key", false, "re-encrypt the secrets of all configurations with the current ENCRYPTION_KEY and exit")
+
 // The main function starts the app by starting all services necessary for this app and waits
 // until all services are finished.
 func main() {
+	flag.Parse()
 	log.Info("main", "Starting the app.")
 
 	// Set default database to use boil.*G functions.
@@ -43,12 +49,29 @@ func main() {
 	// Necessary to close used init resources, because db.Pool() is used in this app.
 	defer db.ClosePool()
 
+	// Encrypt the secrets stored in the database.
+	if err := conf.InitEncryption(); err != nil {
+		log.Fatal("conf", "Cannot initialize encryption: %v", err)
+		return
+	}
+	if *rotateKey {
+		count, err := conf.RotateEncryptionKey(context.Background())
+		if err != nil {
+			log.Fatal("conf", "Cannot rotate encryption key: %v", err)
+			return
+		}
+		log.Info("conf", "Re-encrypted the secrets of %d configurations.", count)
+		return
+	}
+
 	// Initialize the app
 	initialization()
 
 	// Starting the service to collect the data for this app.
 	common.WaitForWithOs(
 		common.Loop(sendData, time.Second),
+		common.Loop(backfill, time.Second),
+		common.Loop(reconcileMappingRules, reconcileInterval),
 		listenApi,
 	)
 
@@ -0,0 +1,47 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"time"
+	"zevvy/conf"
+)
+
+// reconcileInterval defines how often the mapping rules are expanded to asset attributes, so new assets
+// in Eliona are mapped and removed ones are retired.
+const reconcileInterval = 5 * time.Minute
+
+func reconcileMappingRules() {
+	ctx := context.Background()
+	dbMappingRules, err := conf.GetDbEnabledMappingRules(ctx)
+	if err != nil {
+		log.Error("conf", "Couldn't read mapping rules from DB: %v", err)
+		return
+	}
+
+	for _, dbMappingRule := range dbMappingRules {
+		result, err := conf.ReconcileMappingRule(ctx, dbMappingRule)
+		if err != nil {
+			log.Error("main", "Cannot reconcile mapping rule %d: %v", dbMappingRule.ID, err)
+			continue
+		}
+		if result.Created > 0 || result.Retired > 0 || result.Reactivated > 0 || result.Deleted > 0 {
+			log.Info("main", "Mapping rule %d created %d, retired %d, reactivated %d and deleted %d asset attributes.", dbMappingRule.ID, result.Created, result.Retired, result.Reactivated, result.Deleted)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"time"
+	"zevvy/appdb"
+	"zevvy/conf"
+)
+
+const namespace = "zevvy"
+
+var (
+	measurementsRead = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "measurements_read_total",
+		Help:      "Number of measurements read from Eliona.",
+	}, []string{"config"})
+
+	measurementsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "measurements_sent_total",
+		Help:      "Number of measurements sent to Zevvy.",
+	}, []string{"config"})
+
+	measurementsRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "measurements_rejected_total",
+		Help:      "Number of measurements rejected by Zevvy and moved to the dead letters.",
+	}, []string{"config"})
+
+	requests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "Number of requests sent to Zevvy by operation and HTTP status code. Requests without response have the code 0.",
+	}, []string{"operation", "code"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests sent to Zevvy.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	tokenRefreshes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "token_refreshes_total",
+		Help:      "Number of access token refreshes by result.",
+	}, []string{"config", "result"})
+
+	syncDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "sync_duration_seconds",
+		Help:      "Duration of a sync run of a configuration.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, []string{"config"})
+)
+
+func init() {
+	prometheus.MustRegister(stateCollector{})
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+func MeasurementsRead(configId int64, count int) {
+	measurementsRead.WithLabelValues(label(configId)).Add(float64(count))
+}
+
+func MeasurementsSent(configId int64, count int) {
+	measurementsSent.WithLabelValues(label(configId)).Add(float64(count))
+}
+
+func MeasurementsRejected(configId int64, count int) {
+	measurementsRejected.WithLabelValues(label(configId)).Add(float64(count))
+}
+
+// Request records a request sent to Zevvy. A status code of 0 means no response was received.
+func Request(operation string, statusCode int, duration time.Duration) {
+	requests.WithLabelValues(operation, strconv.Itoa(statusCode)).Inc()
+	requestDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+func TokenRefresh(configId int64, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	tokenRefreshes.WithLabelValues(label(configId), result).Inc()
+}
+
+func SyncDuration(configId int64, duration time.Duration) {
+	syncDuration.WithLabelValues(label(configId)).Observe(duration.Seconds())
+}
+
+func label(configId int64) string {
+	return strconv.FormatInt(configId, 10)
+}
+
+var (
+	loggedInDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "logged_in"),
+		"1 if the configuration has a valid access token for Zevvy, otherwise 0.",
+		[]string{"config"}, nil,
+	)
+	attributeLagDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "attribute_lag_seconds"),
+		"Time since the latest timestamp of data read from Eliona for the asset attribute.",
+		[]string{"config", "asset", "subtype", "attribute"}, nil,
+	)
+	outboxDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "outbox_measurements"),
+		"Number of measurements queued in the outbox.",
+		[]string{"config"}, nil,
+	)
+)
+
+// stateCollector reads the state of configurations and asset attributes from the database when
+// the metrics are scraped, so stalled attributes are visible even if the sync stops running.
+type stateCollector struct{}
+
+func (c stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- loggedInDesc
+	ch <- attributeLagDesc
+	ch <- outboxDesc
+}
+
+func (c stateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+	dbConfigs, err := conf.GetDbConfigs(ctx)
+	if err != nil {
+		log.Error("metrics", "Cannot read configs from DB: %v", err)
+		return
+	}
+	now := time.Now()
+	for _, dbConfig := range dbConfigs {
+		loggedIn := 0.0
+		if conf.IsAccessTokenIsValid(dbConfig) {
+			loggedIn = 1
+		}
+		ch <- prometheus.MustNewConstMetric(loggedInDesc, prometheus.GaugeValue, loggedIn, label(dbConfig.ID))
+
+		dbAssetAttributes, err := conf.GetDbAssetAttributes(ctx, dbConfig.ID)
+		if err != nil {
+			log.Error("metrics", "Cannot read asset attributes from DB: %v", err)
+			continue
+		}
+		for _, dbAssetAttribute := range dbAssetAttributes {
+			ch <- prometheus.MustNewConstMetric(attributeLagDesc, prometheus.GaugeValue, now.Sub(dbAssetAttribute.LatestTS).Seconds(),
+				label(dbConfig.ID), fmt.Sprint(dbAssetAttribute.AssetID), dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName)
+		}
+
+		queued, err := appdb.Outboxes(appdb.OutboxWhere.ConfigID.EQ(dbConfig.ID)).CountG(ctx)
+		if err != nil {
+			log.Error("metrics", "Cannot count outbox in DB: %v", err)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(outboxDesc, prometheus.GaugeValue, float64(queued), label(dbConfig.ID))
+	}
+}
@@ -15,6 +15,8 @@
 
 package model
 
+import "time"
+
 type Verification struct {
 	DeviceCode              string `json:"device_code"`
 	UserCode                string `json:"user_code"`
@@ -37,7 +39,26 @@ type Token struct {
 	ErrorDescription string  `json:"error_description"`
 }
 
+// Discovery is the OpenID discovery document of the authentication server.
+type Discovery struct {
+	Issuer                      string `json:"issuer"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+// readAtFormat is the timestamp format expected by Zevvy.
+const readAtFormat = "2006-01-02T15:04:05.000Z"
+
 type Measurement struct {
-	ReadAt string `json:"readAt"`
-	Value  *int   `json:"value"`
+	ReadAt    string    `json:"readAt"`
+	Value     *float64  `json:"value"`
+	Timestamp time.Time `json:"-"`
+}
+
+func NewMeasurement(timestamp time.Time, value *float64) Measurement {
+	return Measurement{
+		ReadAt:    timestamp.UTC().Format(readAtFormat),
+		Value:     value,
+		Timestamp: timestamp,
+	}
 }
@@ -0,0 +1,127 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"net/http"
+	"zevvy/appdb"
+	"zevvy/conf"
+	"zevvy/metrics"
+	"zevvy/model"
+	"zevvy/zevvy"
+)
+
+// drainOutbox sends the queued measurements of the asset attribute to Zevvy batch by batch. Sent
+// measurements are removed from the outbox. Measurements rejected by Zevvy are moved to the dead
+// letters, so they don't block the measurements queued after them.
+func drainOutbox(ctx context.Context, dbConfig *appdb.Configuration, dbAssetAttribute *appdb.AssetAttribute) error {
+	for {
+		dbOutboxes, err := conf.GetDbOutbox(ctx, dbAssetAttribute, zevvy.BatchSize(dbConfig))
+		if err != nil {
+			return fmt.Errorf("getting outbox: %w", err)
+		}
+		if len(dbOutboxes) == 0 {
+			return nil
+		}
+
+		err = zevvy.SendMeasurements(dbConfig, dbAssetAttribute, measurementsFromOutbox(dbOutboxes))
+		if isRejected(err) {
+			if err := rejectOutbox(ctx, dbConfig, dbAssetAttribute, dbOutboxes, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			if err := conf.UpdateOutboxError(ctx, dbOutboxes, err); err != nil {
+				log.Error("conf", "Cannot update outbox error: %v", err)
+			}
+			return fmt.Errorf("sending measurements to Zevvy: %w", err)
+		}
+
+		if err := conf.AckOutbox(ctx, dbAssetAttribute, dbOutboxes); err != nil {
+			return fmt.Errorf("acknowledging outbox: %w", err)
+		}
+		metrics.MeasurementsSent(dbAssetAttribute.ConfigID, len(dbOutboxes))
+		log.Debug("main", "Sent %d measurements for attribute %d %s %s.", len(dbOutboxes), dbAssetAttribute.AssetID, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName)
+	}
+}
+
+// rejectOutbox handles a batch rejected by Zevvy. Because Zevvy rejects the whole batch, the
+// measurements are sent one by one to find the poisoned ones, which are moved to the dead letters.
+func rejectOutbox(ctx context.Context, dbConfig *appdb.Configuration, dbAssetAttribute *appdb.AssetAttribute, dbOutboxes []*appdb.Outbox, rejectErr error) error {
+	if len(dbOutboxes) == 1 {
+		return deadLetter(ctx, dbAssetAttribute, dbOutboxes, rejectErr)
+	}
+	for _, dbOutbox := range dbOutboxes {
+		single := []*appdb.Outbox{dbOutbox}
+		err := zevvy.SendMeasurements(dbConfig, dbAssetAttribute, measurementsFromOutbox(single))
+		if isRejected(err) {
+			if err := deadLetter(ctx, dbAssetAttribute, single, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			if err := conf.UpdateOutboxError(ctx, single, err); err != nil {
+				log.Error("conf", "Cannot update outbox error: %v", err)
+			}
+			return fmt.Errorf("sending measurement to Zevvy: %w", err)
+		}
+		if err := conf.AckOutbox(ctx, dbAssetAttribute, single); err != nil {
+			return fmt.Errorf("acknowledging outbox: %w", err)
+		}
+		metrics.MeasurementsSent(dbAssetAttribute.ConfigID, len(single))
+	}
+	return nil
+}
+
+func deadLetter(ctx context.Context, dbAssetAttribute *appdb.AssetAttribute, dbOutboxes []*appdb.Outbox, rejectErr error) error {
+	var zevvyErr *zevvy.Error
+	errors.As(rejectErr, &zevvyErr)
+	log.Warn("main", "Zevvy rejected %d measurements for attribute %d %s %s: %v", len(dbOutboxes), dbAssetAttribute.AssetID, dbAssetAttribute.Subtype, dbAssetAttribute.AttributeName, rejectErr)
+	if err := conf.DeadLetterOutbox(ctx, dbAssetAttribute, dbOutboxes, zevvyErr.StatusCode, rejectErr, zevvyErr.Body); err != nil {
+		return fmt.Errorf("moving outbox to dead letters: %w", err)
+	}
+	metrics.MeasurementsRejected(dbAssetAttribute.ConfigID, len(dbOutboxes))
+	return nil
+}
+
+// isRejected returns true if Zevvy refused the measurements themselves. Errors caused by the
+// authentication or by a temporary problem are not a reason to give up the measurements.
+func isRejected(err error) bool {
+	var zevvyErr *zevvy.Error
+	if !errors.As(err, &zevvyErr) || zevvyErr.Retryable {
+		return false
+	}
+	switch zevvyErr.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false
+	}
+	return zevvyErr.StatusCode >= http.StatusBadRequest && zevvyErr.StatusCode < http.StatusInternalServerError
+}
+
+func measurementsFromOutbox(dbOutboxes []*appdb.Outbox) []model.Measurement {
+	var measurements []model.Measurement
+	for _, dbOutbox := range dbOutboxes {
+		value := dbOutbox.Value
+		measurements = append(measurements, model.NewMeasurement(dbOutbox.ReadAt, &value))
+	}
+	return measurements
+}
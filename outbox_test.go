@@ -0,0 +1,117 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+	"zevvy/appdb"
+	"zevvy/zevvy"
+)
+
+func TestOutboxErrorClassification(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		rejected bool
+	}{
+		{name: "sent", err: nil},
+		{name: "invalid value", err: &zevvy.Error{StatusCode: http.StatusBadRequest}, rejected: true},
+		{name: "unknown register", err: &zevvy.Error{StatusCode: http.StatusNotFound}, rejected: true},
+		{name: "already stored", err: &zevvy.Error{StatusCode: http.StatusConflict}, rejected: true},
+		{name: "already stored wrapped", err: fmt.Errorf("sending: %w", &zevvy.Error{StatusCode: http.StatusConflict}), rejected: true},
+		{name: "unauthorized", err: &zevvy.Error{StatusCode: http.StatusUnauthorized}},
+		{name: "forbidden", err: &zevvy.Error{StatusCode: http.StatusForbidden}},
+		{name: "rate limited", err: &zevvy.Error{StatusCode: http.StatusTooManyRequests, Retryable: true}},
+		{name: "server error", err: &zevvy.Error{StatusCode: http.StatusBadGateway, Retryable: true}},
+		{name: "network error", err: errors.New("connection refused")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRejected(tt.err); got != tt.rejected {
+				t.Errorf("isRejected = %v, want %v", got, tt.rejected)
+			}
+		})
+	}
+}
+
+// capturedValue records the argument of a statement.
+type capturedValue struct {
+	values *[]driver.Value
+}
+
+func (c capturedValue) Match(value driver.Value) bool {
+	*c.values = append(*c.values, value)
+	return true
+}
+
+// A measurement Zevvy refuses, because it already has one at the same time, may have a different value.
+// It is kept as dead letter with the response of Zevvy instead of being counted as sent.
+func TestDrainOutboxDeadLettersConflict(t *testing.T) {
+	const responseBody = `{"message":"measurement already exists"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(responseBody))
+	}))
+	defer server.Close()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("creating database mock: %v", err)
+	}
+	previous := boil.GetDB()
+	boil.SetDB(db)
+	defer func() {
+		boil.SetDB(previous)
+		_ = db.Close()
+	}()
+
+	readAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	outboxColumns := []string{"id", "config_id", "asset_id", "subtype", "attribute_name", "read_at", "value"}
+	mock.ExpectQuery(`FROM "zevvy"."outbox"`).WillReturnRows(sqlmock.NewRows(outboxColumns).AddRow(1, 1, 4711, "input", "energy", readAt, 42.5))
+	mock.ExpectBegin()
+	var inserted []driver.Value
+	args := make([]driver.Value, 12)
+	for i := range args {
+		args[i] = capturedValue{values: &inserted}
+	}
+	mock.ExpectQuery(`INSERT INTO "zevvy"."dead_letter"`).WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(`DELETE FROM "zevvy"."outbox"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`FROM "zevvy"."outbox"`).WillReturnRows(sqlmock.NewRows(outboxColumns))
+
+	dbConfig := &appdb.Configuration{ID: 1, APIRootURL: server.URL, RequestTimeout: 5}
+	dbAssetAttribute := &appdb.AssetAttribute{ConfigID: 1, AssetID: 4711, Subtype: "input", AttributeName: "energy", DeviceReference: "meter", RegisterReference: "energy"}
+	if err := drainOutbox(context.Background(), dbConfig, dbAssetAttribute); err != nil {
+		t.Fatalf("draining outbox: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(inserted, driver.Value(int64(http.StatusConflict))) || !slices.Contains(inserted, driver.Value(responseBody)) {
+		t.Errorf("dead letter %v doesn't hold the status and the response of Zevvy", inserted)
+	}
+}
@@ -16,100 +16,163 @@
 package zevvy
 
 import (
+	"encoding/json"
 	"fmt"
 	utilshttp "github.com/eliona-smart-building-assistant/go-utils/http"
-	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"net/http"
 	"net/url"
-	"time"
 	"zevvy/appdb"
 	"zevvy/model"
 )
 
 func GetVerification(dbConfig *appdb.Configuration) (*model.Verification, error) {
 	fullUrl := dbConfig.AuthRootURL + "/protocol/openid-connect/auth/device"
-	request, err := utilshttp.NewPostFormRequestWithHeaders(
-		fullUrl,
-		map[string][]string{
-			"client_id":     {dbConfig.ClientID},
-			"client_secret": {dbConfig.ClientSecret},
-			"scope":         {"offline_access measurement register device"},
-		}, map[string]string{},
-	)
+	resp, err := send(dbConfig, "device_authorization", func() (*http.Request, error) {
+		return utilshttp.NewPostFormRequestWithHeaders(
+			fullUrl,
+			map[string][]string{
+				"client_id":     {dbConfig.ClientID},
+				"client_secret": {dbConfig.ClientSecret},
+				"scope":         {"offline_access measurement register device"},
+			}, map[string]string{},
+		)
+	})
 	if err != nil {
 		return nil, err
 	}
-	verification, statusCode, err := utilshttp.ReadWithStatusCode[*model.Verification](request, time.Duration(dbConfig.RequestTimeout)*time.Second, true)
-	if err != nil || statusCode != http.StatusOK {
-		return nil, fmt.Errorf("error reading request for %s: %d %w", fullUrl, statusCode, err)
+	if resp.statusCode != http.StatusOK {
+		return nil, statusError(fullUrl, resp)
 	}
-
-	return verification, nil
+	return decode[*model.Verification](fullUrl, resp)
 }
 
 func GetTokens(dbConfig *appdb.Configuration) (*model.Token, error) {
 	fullUrl := dbConfig.AuthRootURL + "/protocol/openid-connect/token"
-	request, err := utilshttp.NewPostFormRequestWithHeaders(
-		fullUrl,
-		map[string][]string{
-			"client_id":     {dbConfig.ClientID},
-			"client_secret": {dbConfig.ClientSecret},
-			"device_code":   {dbConfig.DeviceCode.String},
-			"grant_type":    {"urn:ietf:params:oauth:grant-type:device_code"},
-		}, map[string]string{},
-	)
+	resp, err := send(dbConfig, "device_token", func() (*http.Request, error) {
+		return utilshttp.NewPostFormRequestWithHeaders(
+			fullUrl,
+			map[string][]string{
+				"client_id":     {dbConfig.ClientID},
+				"client_secret": {dbConfig.ClientSecret},
+				"device_code":   {dbConfig.DeviceCode.String},
+				"grant_type":    {"urn:ietf:params:oauth:grant-type:device_code"},
+			}, map[string]string{},
+		)
+	})
 	if err != nil {
 		return nil, err
 	}
-	token, statusCode, err := utilshttp.ReadWithStatusCode[*model.Token](request, time.Duration(dbConfig.RequestTimeout)*time.Second, true)
-	if token.Error != nil {
-		return nil, fmt.Errorf("status reading request for config %d: %s %s", dbConfig.ID, *token.Error, token.ErrorDescription)
-	}
-	if err != nil || statusCode != http.StatusOK {
-		return nil, fmt.Errorf("error reading request for %s: %d %w", fullUrl, statusCode, err)
-	}
-
-	return token, nil
+	return tokenFromResponse(dbConfig, fullUrl, resp)
 }
 
 func RefreshTokens(dbConfig *appdb.Configuration) (*model.Token, error) {
 	fullUrl := dbConfig.AuthRootURL + "/protocol/openid-connect/token"
-	request, err := utilshttp.NewPostFormRequestWithHeaders(
-		fullUrl,
-		map[string][]string{
-			"client_id":     {dbConfig.ClientID},
-			"client_secret": {dbConfig.ClientSecret},
-			"device_code":   {dbConfig.DeviceCode.String},
-			"grant_type":    {"refresh_token"},
-			"refresh_token": {dbConfig.RefreshToken.String},
-		}, map[string]string{},
-	)
+	resp, err := send(dbConfig, "token_refresh", func() (*http.Request, error) {
+		return utilshttp.NewPostFormRequestWithHeaders(
+			fullUrl,
+			map[string][]string{
+				"client_id":     {dbConfig.ClientID},
+				"client_secret": {dbConfig.ClientSecret},
+				"device_code":   {dbConfig.DeviceCode.String},
+				"grant_type":    {"refresh_token"},
+				"refresh_token": {dbConfig.RefreshToken.String},
+			}, map[string]string{},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tokenFromResponse(dbConfig, fullUrl, resp)
+}
+
+// GetClientCredentialsTokens gets an access token with the client credentials grant. No user
+// interaction is needed, so this can be used for unattended installations.
+func GetClientCredentialsTokens(dbConfig *appdb.Configuration) (*model.Token, error) {
+	fullUrl := dbConfig.AuthRootURL + "/protocol/openid-connect/token"
+	resp, err := send(dbConfig, "client_credentials", func() (*http.Request, error) {
+		return utilshttp.NewPostFormRequestWithHeaders(
+			fullUrl,
+			map[string][]string{
+				"client_id":     {dbConfig.ClientID},
+				"client_secret": {dbConfig.ClientSecret},
+				"grant_type":    {"client_credentials"},
+				"scope":         {"measurement register device"},
+			}, map[string]string{},
+		)
+	})
 	if err != nil {
 		return nil, err
 	}
-	token, statusCode, err := utilshttp.ReadWithStatusCode[*model.Token](request, time.Duration(dbConfig.RequestTimeout)*time.Second, true)
+	return tokenFromResponse(dbConfig, fullUrl, resp)
+}
+
+// Error codes returned by the token endpoint (RFC 6749 5.2 and RFC 8628 3.5).
+const (
+	// ErrorInvalidGrant is returned if the refresh token is expired or revoked.
+	ErrorInvalidGrant = "invalid_grant"
+	// ErrorAuthorizationPending is returned while the user hasn't verified the login yet.
+	ErrorAuthorizationPending = "authorization_pending"
+	// ErrorSlowDown is returned if the token endpoint is polled too often.
+	ErrorSlowDown = "slow_down"
+	// ErrorExpiredToken is returned if the device code expired before the user verified the login.
+	ErrorExpiredToken = "expired_token"
+	// ErrorAccessDenied is returned if the user denied the access.
+	ErrorAccessDenied = "access_denied"
+)
+
+// tokenFromResponse reads the token from the response of the token endpoint. OAuth errors like
+// authorization_pending are returned in the response body together with a 4xx status code.
+func tokenFromResponse(dbConfig *appdb.Configuration, fullUrl string, resp *response) (*model.Token, error) {
+	token, err := decode[*model.Token](fullUrl, resp)
 	if token != nil && token.Error != nil {
-		return nil, fmt.Errorf("status reading request for config %d: %s %s", dbConfig.ID, *token.Error, token.ErrorDescription)
+		return nil, fmt.Errorf("status reading request for config %d: %w", dbConfig.ID, &OAuthError{
+			Code:        *token.Error,
+			Description: token.ErrorDescription,
+			Err:         statusError(fullUrl, resp),
+		})
 	}
-	if err != nil || statusCode != http.StatusOK {
-		return nil, fmt.Errorf("error reading request for %s: %d %w", fullUrl, statusCode, err)
+	if resp.statusCode != http.StatusOK {
+		return nil, statusError(fullUrl, resp)
+	}
+	if err != nil {
+		return nil, err
 	}
-
 	return token, nil
 }
 
+// defaultBatchSize is used if no valid batch size is defined in the configuration.
+const defaultBatchSize = 1000
+
+// BatchSize returns the maximum number of measurements sent to Zevvy in one request.
+func BatchSize(dbConfig *appdb.Configuration) int {
+	if dbConfig.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return int(dbConfig.BatchSize)
+}
+
 func SendMeasurements(dbConfig *appdb.Configuration, dbAssetAttribute *appdb.AssetAttribute, measurements []model.Measurement) error {
 	fullUrl := dbConfig.APIRootURL + fmt.Sprintf("/deviceRef/%s/registerRef/%s/measurements/_bulk_create", url.PathEscape(dbAssetAttribute.DeviceReference), url.PathEscape(dbAssetAttribute.RegisterReference))
-	request, err := utilshttp.NewPostRequestWithBearer(fullUrl, measurements, dbConfig.AccessToken.String)
+	resp, err := send(dbConfig, "measurements", func() (*http.Request, error) {
+		return utilshttp.NewPostRequestWithBearer(fullUrl, measurements, dbConfig.AccessToken.String)
+	})
 	if err != nil {
 		return err
 	}
-	_, statusCode, err := utilshttp.ReadWithStatusCode[any](request, time.Duration(dbConfig.RequestTimeout)*time.Second, true)
-	if err != nil || (statusCode != http.StatusCreated && statusCode != http.StatusConflict) {
-		return fmt.Errorf("error reading request for %s: %d %w", fullUrl, statusCode, err)
-	}
-	if statusCode == http.StatusConflict {
-		log.Warn("Zevvy", "Conflict for %s", fullUrl)
+	if resp.statusCode != http.StatusCreated {
+		return statusError(fullUrl, resp)
 	}
 	return nil
 }
+
+// decode unmarshals the JSON body of the response.
+func decode[T any](fullUrl string, resp *response) (T, error) {
+	var value T
+	if len(resp.body) == 0 {
+		return value, nil
+	}
+	if err := json.Unmarshal(resp.body, &value); err != nil {
+		return value, &Error{Url: fullUrl, StatusCode: resp.statusCode, Body: string(resp.body), Err: fmt.Errorf("unmarshaling: %w", err)}
+	}
+	return value, nil
+}
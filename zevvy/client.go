@@ -0,0 +1,225 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package zevvy
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+	"zevvy/appdb"
+	"zevvy/metrics"
+)
+
+const (
+	// maxAttempts is the number of times a request is sent before giving up.
+	maxAttempts = 4
+	// baseBackoff is the delay before the first retry. It doubles with each further retry.
+	baseBackoff = time.Second
+	// maxBackoff limits the delay between two retries.
+	maxBackoff = 30 * time.Second
+	// maxRetryWait limits the time a request waits for retries in total, so a slow Zevvy doesn't block
+	// the other asset attributes. If Zevvy asks to wait longer, e.g. by a Retry-After header, the
+	// request is not retried and the error with the delay is returned, so the caller schedules the retry.
+	maxRetryWait = 30 * time.Second
+)
+
+// httpClient is shared by all requests, so connections to Zevvy are reused. The timeout of each
+// request is set by its context.
+var httpClient = &http.Client{
+	Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{},
+	},
+}
+
+// Error describes a failed request to Zevvy. Retryable errors are temporary (network problems,
+// rate limits or server errors) and the same request may succeed later. All other errors are
+// permanent and the request must be changed before it can succeed.
+type Error struct {
+	Url        string
+	StatusCode int
+	Body       string
+	Retryable  bool
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("request to %s failed: %v", e.Url, e.Err)
+	}
+	return fmt.Sprintf("request to %s failed with status %d: %s", e.Url, e.StatusCode, e.Body)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// OAuthError is an error response of the token endpoint as defined in RFC 6749 and RFC 8628.
+type OAuthError struct {
+	Code        string
+	Description string
+	Err         error
+}
+
+func (e *OAuthError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Code, e.Description, e.Err)
+}
+
+func (e *OAuthError) Unwrap() error {
+	return e.Err
+}
+
+// IsOAuthError returns true if the error is an OAuth error response with the given error code.
+func IsOAuthError(err error, code string) bool {
+	var oauthErr *OAuthError
+	return errors.As(err, &oauthErr) && oauthErr.Code == code
+}
+
+// IsRetryable returns true if the error is temporary and the request can be repeated later.
+func IsRetryable(err error) bool {
+	var zevvyErr *Error
+	if errors.As(err, &zevvyErr) {
+		return zevvyErr.Retryable
+	}
+	return false
+}
+
+// response holds the result of a request sent to Zevvy.
+type response struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// send sends the request created by newRequest to Zevvy. Network errors and retryable status codes are
+// retried with exponential backoff and jitter, honoring the Retry-After header on 429 and 503 responses.
+// The last response is returned regardless of its status code, so the caller can decide about success.
+// The operation names the request in the metrics.
+func send(dbConfig *appdb.Configuration, operation string, newRequest func() (*http.Request, error)) (*response, error) {
+	var waited time.Duration
+	for attempt := 1; ; attempt++ {
+		request, err := newRequest()
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+
+		start := time.Now()
+		resp, err := do(request, time.Duration(dbConfig.RequestTimeout)*time.Second)
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.statusCode
+		}
+		metrics.Request(operation, statusCode, time.Since(start))
+		if err != nil {
+			err = &Error{Url: request.URL.String(), Retryable: true, Err: err}
+		} else if isRetryableStatus(resp.statusCode) {
+			err = statusError(request.URL.String(), resp)
+		} else {
+			return resp, nil
+		}
+
+		var zevvyErr *Error
+		errors.As(err, &zevvyErr)
+		delay := zevvyErr.RetryAfter
+		if delay <= 0 {
+			delay = backoff(attempt)
+		}
+		if attempt >= maxAttempts || waited+delay > maxRetryWait {
+			return resp, err
+		}
+		waited += delay
+		log.Warn("Zevvy", "Retrying request to %s in %v (attempt %d of %d): %v", request.URL, delay, attempt, maxAttempts, err)
+		time.Sleep(delay)
+	}
+}
+
+// do sends the request with the shared client and reads the response within the timeout.
+func do(request *http.Request, timeout time.Duration) (*response, error) {
+	ctx, cancel := context.WithTimeout(request.Context(), timeout)
+	defer cancel()
+	httpResponse, err := httpClient.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(body io.ReadCloser) {
+		if err := body.Close(); err != nil {
+			log.Error("Zevvy", "Error closing response for %s: %v", request.URL, err)
+		}
+	}(httpResponse.Body)
+
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	return &response{
+		statusCode: httpResponse.StatusCode,
+		header:     httpResponse.Header,
+		body:       body,
+	}, nil
+}
+
+// statusError creates the error for a response with an unexpected status code.
+func statusError(url string, resp *response) *Error {
+	return &Error{
+		Url:        url,
+		StatusCode: resp.statusCode,
+		Body:       string(resp.body),
+		Retryable:  isRetryableStatus(resp.statusCode),
+		RetryAfter: retryAfter(resp),
+	}
+}
+
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return true
+	case http.StatusNotImplemented, http.StatusHTTPVersionNotSupported:
+		return false
+	}
+	return statusCode >= http.StatusInternalServerError
+}
+
+// retryAfter returns the delay requested by the Retry-After header of 429 and 503 responses. The
+// header contains either the delay in seconds or the date after which the request can be repeated.
+func retryAfter(resp *response) time.Duration {
+	if resp.statusCode != http.StatusTooManyRequests && resp.statusCode != http.StatusServiceUnavailable {
+		return 0
+	}
+	value := resp.header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+// backoff returns the delay before the next retry with full jitter.
+func backoff(attempt int) time.Duration {
+	delay := min(baseBackoff<<(attempt-1), maxBackoff)
+	return rand.N(delay) + 1
+}
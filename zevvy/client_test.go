@@ -0,0 +1,70 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package zevvy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"zevvy/appdb"
+)
+
+// A Retry-After longer than the retries may wait is returned at once, so the caller schedules the retry
+// instead of blocking the other asset attributes.
+func TestSendReturnsLongRetryAfter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	_, err := send(&appdb.Configuration{RequestTimeout: 5}, "test", func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	})
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("send blocked for %v", elapsed)
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+	if !IsRetryable(err) {
+		t.Fatalf("error %v is not retryable", err)
+	}
+	if zevvyErr := err.(*Error); zevvyErr.RetryAfter != 2*time.Minute {
+		t.Errorf("retry after %v, want 2m", zevvyErr.RetryAfter)
+	}
+}
+
+func TestSendTimesOutRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := do(request, 50*time.Millisecond); err == nil {
+		t.Fatal("request without response succeeded")
+	}
+}
@@ -0,0 +1,74 @@
+//  This file is part of the eliona project.
+//  Copyright © 2024 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package zevvy
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"zevvy/model"
+)
+
+// verifyTimeout limits the requests checking a configuration, because the client of the API
+// waits for the result.
+const verifyTimeout = 10 * time.Second
+
+// CheckDiscovery requests the OpenID discovery document of the authentication root URL and checks
+// that it defines a token endpoint.
+func CheckDiscovery(authRootUrl string) error {
+	fullUrl := authRootUrl + "/.well-known/openid-configuration"
+	resp, err := probe(fullUrl)
+	if err != nil {
+		return err
+	}
+	if resp.statusCode != http.StatusOK {
+		return statusError(fullUrl, resp)
+	}
+	discovery, err := decode[*model.Discovery](fullUrl, resp)
+	if err != nil {
+		return err
+	}
+	if discovery == nil || discovery.TokenEndpoint == "" {
+		return fmt.Errorf("no token endpoint defined in %s", fullUrl)
+	}
+	return nil
+}
+
+// CheckApiRoot checks that the API root URL is reachable. Any response except server errors is
+// accepted, because the request is sent without access token.
+func CheckApiRoot(apiRootUrl string) error {
+	resp, err := probe(apiRootUrl)
+	if err != nil {
+		return err
+	}
+	if resp.statusCode >= http.StatusInternalServerError {
+		return statusError(apiRootUrl, resp)
+	}
+	return nil
+}
+
+// probe sends a single GET request without retries.
+func probe(fullUrl string) (*response, error) {
+	request, err := http.NewRequest(http.MethodGet, fullUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := do(request, verifyTimeout)
+	if err != nil {
+		return nil, &Error{Url: fullUrl, Retryable: true, Err: err}
+	}
+	return resp, nil
+}